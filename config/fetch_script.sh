@@ -1,46 +1,50 @@
-echo -e "${C3}Distribution: ${C4}${DISTRO_LONG_NAME} ($(uname -m))"
-echo -e "${C3}Hostname: ${C4}$(cat /etc/hostname)"
-echo -e "${C3}Kernel: ${C4}$(uname -s) $(uname -r)"
-echo -e "${C3}Packages: ${C4}${PACKAGES}"
-echo -e "${C3}Shell: ${C4}${USER_SHELL}"
-echo -e "${C3}Init: ${C4}${INIT_SYSTEM}"
-echo -e "${C3}Libc: ${C4}${LIBC}"
-[ -n "$MOTHERBOARD" ] && echo -e "${C3}Motherboard: ${C4}${MOTHERBOARD}"
-[ -n "$CPU_MODEL" ] && echo -e "${C3}CPU: ${C4}${CPU_MODEL} (${CPU_THREADS} threads)"
+echo -e "${C_LABEL}Distribution${C_SEPARATOR}: ${C_VALUE}${DISTRO_LONG_NAME} ($(uname -m))"
+echo -e "${C_LABEL}Hostname${C_SEPARATOR}: ${C_VALUE}$(cat /etc/hostname)"
+echo -e "${C_LABEL}Kernel${C_SEPARATOR}: ${C_VALUE}$(uname -s) $(uname -r)"
+echo -e "${C_LABEL}Packages${C_SEPARATOR}: ${C_VALUE}${PACKAGES}"
+echo -e "${C_LABEL}Shell${C_SEPARATOR}: ${C_VALUE}${USER_SHELL}"
+echo -e "${C_LABEL}Init${C_SEPARATOR}: ${C_VALUE}${INIT_SYSTEM}"
+echo -e "${C_LABEL}Libc${C_SEPARATOR}: ${C_VALUE}${LIBC}"
+[ -n "$MOTHERBOARD" ] && echo -e "${C_LABEL}Motherboard${C_SEPARATOR}: ${C_VALUE}${MOTHERBOARD}"
+[ -n "$CPU_MODEL" ] && echo -e "${C_LABEL}CPU${C_SEPARATOR}: ${C_VALUE}${CPU_MODEL} (${CPU_THREADS} threads)"
 for i in $(seq "${CONNECTED_GPUS}"); do
     gpu="GPU$i"
-    echo -e "${C3}GPU: ${C4}${!gpu}"
+    echo -e "${C_LABEL}GPU${C_SEPARATOR}: ${C_VALUE}${!gpu}"
   done
-[ -n "$MEM_TOTAL" ] && [ -n "$MEM_USED" ] && echo -e "${C3}Memory: ${C4}${MEM_USED} MiB / ${MEM_TOTAL} MiB"
+[ -n "$MEM_TOTAL" ] && [ -n "$MEM_USED" ] && echo -e "${C_LABEL}Memory${C_SEPARATOR}: ${C_VALUE}${MEM_USED} MiB / ${MEM_TOTAL} MiB"
 for i in $(seq "${MOUNTED_PARTITIONS}"); do
   mountpoint="PARTITION${i}_MOUNTPOINT"
   label="PARTITION${i}_LABEL"
   type="PARTITION${i}_TYPE"
   total="PARTITION${i}_TOTAL_SIZE"
   used="PARTITION${i}_USED_SIZE"
+  delta="PARTITION${i}_USED_DELTA"
+  trend=""
+  [ -n "${!delta}" ] && trend=" (${!delta} in ${HISTORY_DELTA_DAYS} days)"
   if [ -z "${!type}" ]; then
     if [ -z "${!label}" ]; then
-      echo -e "${C3}Partition ${!mountpoint}: ${C4}${!used}/${!total}"
+      echo -e "${C_LABEL}Partition ${!mountpoint}${C_SEPARATOR}: ${C_VALUE}${!used}/${!total}${trend}"
     else
-      echo -e "${C3}Partition ${!label}: ${C4}${!used}/${!total}"
+      echo -e "${C_LABEL}Partition ${!label}${C_SEPARATOR}: ${C_VALUE}${!used}/${!total}${trend}"
     fi
   else
     if [ -z "${!label}" ]; then
-      echo -e "${C3}Partition ${!mountpoint} (${!type}): ${C4}${!used}/${!total}"
+      echo -e "${C_LABEL}Partition ${!mountpoint} (${!type})${C_SEPARATOR}: ${C_VALUE}${!used}/${!total}${trend}"
     else
-      echo -e "${C3}Partition ${!label} (${!type}): ${C4}${!used}/${!total}"
+      echo -e "${C_LABEL}Partition ${!label} (${!type})${C_SEPARATOR}: ${C_VALUE}${!used}/${!total}${trend}"
     fi
   fi
 done
-[ -n "$LOCAL_IPV4" ] && echo -e "${C3}Local IPv4 Address: ${C4}${LOCAL_IPV4}"
+[ -n "$LOCAL_IPV4" ] && echo -e "${C_LABEL}Local IPv4 Address${C_SEPARATOR}: ${C_VALUE}${LOCAL_IPV4}"
 if [ -n "$DISPLAY_PROTOCOL" ]; then
-  echo -e "${C3}Display Protocol: ${C4}${DISPLAY_PROTOCOL}"
+  echo -e "${C_LABEL}Display Protocol${C_SEPARATOR}: ${C_VALUE}${DISPLAY_PROTOCOL}"
   for i in $(seq "${CONNECTED_MONITORS}"); do
     monitor="MONITOR$i"
-    echo -e "${C3}Screen $i: ${C4}${!monitor}"
+    echo -e "${C_LABEL}Screen $i${C_SEPARATOR}: ${C_VALUE}${!monitor}"
   done
 fi
-[ -n "$DE_WM" ] && echo -e "${C3}DE/WM: ${C4}${DE_WM}"
+[ -n "$DE_WM" ] && echo -e "${C_LABEL}DE/WM${C_SEPARATOR}: ${C_VALUE}${DE_WM}"
+[ -n "$COLOR_BLOCKS" ] && echo -e "\n${COLOR_BLOCKS}"
 
 # Exiting with error code 0 in case the condition above returns 1
 exit 0
\ No newline at end of file
@@ -0,0 +1,8 @@
+echo -e "${C_LABEL}OS${C_SEPARATOR}: ${C_VALUE}${DISTRO_SHORT_NAME}"
+echo -e "${C_LABEL}Kernel${C_SEPARATOR}: ${C_VALUE}$(uname -r)"
+echo -e "${C_LABEL}Packages${C_SEPARATOR}: ${C_VALUE}${PACKAGES}"
+echo -e "${C_LABEL}Shell${C_SEPARATOR}: ${C_VALUE}${USER_SHELL}"
+[ -n "$MEM_TOTAL" ] && [ -n "$MEM_USED" ] && echo -e "${C_LABEL}Memory${C_SEPARATOR}: ${C_VALUE}${MEM_USED} MiB / ${MEM_TOTAL} MiB"
+
+# Exiting with error code 0 in case the condition above returns 1
+exit 0
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// asciiFrameMarker is the line separating the frames of an animated ascii art
+const asciiFrameMarker = "#/frame"
+
+// defaultFrameDelay is the delay between frames used when the header of an animated art does not set one
+const defaultFrameDelay = 100 * time.Millisecond
+
+// parseAsciiHeaderOptions parses the options following the '|' of a '#/' header, such as 'delay=120ms', returning the frame delay
+func parseAsciiHeaderOptions(options string) (time.Duration, error) {
+	delay := defaultFrameDelay
+	for _, option := range strings.Split(options, ",") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(option, "=")
+		switch strings.TrimSpace(key) {
+		case "delay":
+			value = strings.TrimSpace(value)
+			// Plain numbers are milliseconds
+			if _, err := strconv.Atoi(value); err == nil {
+				value += "ms"
+			}
+			parsed, err := time.ParseDuration(value)
+			if err != nil || parsed <= 0 {
+				return 0, fmt.Errorf("invalid frame delay '%s'", value)
+			}
+			delay = parsed
+		default:
+			return 0, fmt.Errorf("unknown header option '%s'", key)
+		}
+	}
+	return delay, nil
+}
+
+// GetAsciiFrameDelay returns the delay between the frames of an animated art set in its header
+func GetAsciiFrameDelay(ascii string) time.Duration {
+	if !strings.HasPrefix(ascii, "#/") {
+		return defaultFrameDelay
+	}
+	header, _, _ := strings.Cut(ascii, "\n")
+	_, options, _ := strings.Cut(header, "|")
+	delay, err := parseAsciiHeaderOptions(options)
+	if err != nil {
+		return defaultFrameDelay
+	}
+	return delay
+}
+
+// SplitAsciiFrames splits an art without its header into its frames, padded to the same size so they can replace each other in place
+func SplitAsciiFrames(ascii string) []string {
+	var frames [][]string
+	current := []string{}
+	for _, line := range strings.Split(ascii, "\n") {
+		if strings.TrimRight(line, " \t") == asciiFrameMarker {
+			frames = append(frames, current)
+			current = []string{}
+			continue
+		}
+		current = append(current, line)
+	}
+	frames = append(frames, current)
+	if len(frames) == 1 {
+		return []string{ascii}
+	}
+
+	width, height := 0, 0
+	for _, frame := range frames {
+		height = max(height, len(frame))
+		for _, line := range frame {
+			width = max(width, DisplayWidth(line))
+		}
+	}
+	padded := make([]string, len(frames))
+	for i, frame := range frames {
+		for len(frame) < height {
+			frame = append(frame, "")
+		}
+		// Pad the first line so the width of every frame matches, which keeps the layout the same across frames
+		frame[0] = PadRight(frame[0], width)
+		for j, line := range frame {
+			if line == "" {
+				frame[j] = " "
+			}
+		}
+		padded[i] = strings.Join(frame, "\n")
+	}
+	return padded
+}
+
+// AnimationEnabled returns whether animated arts are played rather than only showing their last frame
+func AnimationEnabled() bool {
+	return config.AnimationDuration > 0 && IsTerminal(os.Stdout)
+}
+
+// PlayAnimation prints the frames one after another in place for the configured duration, ending on the last frame.
+// The cursor is moved back to the first line of the output before drawing each frame, so every frame must have the same height
+func PlayAnimation(frames []string, delay time.Duration) {
+	if len(frames) == 1 {
+		fmt.Println(frames[0])
+		return
+	}
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	// Hide the cursor while drawing
+	fmt.Print("\033[?25l")
+	defer fmt.Print("\033[?25h")
+	draw := func(frame string, redraw bool) {
+		lines := strings.Split(frame, "\n")
+		if redraw && len(lines) > 1 {
+			fmt.Printf("\r\033[%dA", len(lines)-1)
+		} else if redraw {
+			fmt.Print("\r")
+		}
+		// Clear what is left of longer lines of the previous frame
+		fmt.Print(strings.Join(lines, "\033[K\n") + "\033[K")
+	}
+
+	draw(frames[0], false)
+	current := 0
+	deadline := time.Now().Add(time.Duration(config.AnimationDuration) * time.Millisecond)
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+playback:
+	for time.Now().Add(delay).Before(deadline) {
+		select {
+		case <-ticker.C:
+			current = (current + 1) % len(frames)
+			draw(frames[current], true)
+		case <-interrupt:
+			break playback
+		}
+	}
+	if current != len(frames)-1 {
+		draw(frames[len(frames)-1], true)
+	}
+	fmt.Println()
+}
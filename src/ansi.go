@@ -0,0 +1,181 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type ColorMode uint8
+
+const (
+	ColorDefault ColorMode = iota
+	Color16
+	Color256
+	ColorRGB
+)
+
+type TermColor struct {
+	Mode    ColorMode
+	Index   uint8
+	R, G, B uint8
+}
+
+// SGRState holds the graphic rendition set by SGR escape sequences
+type SGRState struct {
+	Bold          bool
+	Dim           bool
+	Italic        bool
+	Underline     bool
+	Blink         bool
+	Reverse       bool
+	Hidden        bool
+	Strikethrough bool
+	Foreground    TermColor
+	Background    TermColor
+}
+
+var sgrRegex = regexp.MustCompile("\033\\[([0-9;:]*)m")
+
+// parseSGRParams splits SGR parameters, expanding colon separated sub-parameters such as 38:2::r:g:b
+func parseSGRParams(params string) []int {
+	var ret []int
+	if params == "" {
+		return []int{0}
+	}
+	for _, param := range strings.Split(params, ";") {
+		subParams := strings.Split(param, ":")
+		// Drop the optional color space ID of 38:2:ID:r:g:b
+		if len(subParams) == 6 && subParams[1] == "2" {
+			subParams = append(subParams[:2], subParams[3:]...)
+		}
+		for _, subParam := range subParams {
+			num, err := strconv.Atoi(subParam)
+			if err != nil {
+				num = 0
+			}
+			ret = append(ret, num)
+		}
+	}
+	return ret
+}
+
+// parseExtendedColor parses the parameters following 38 or 48 and returns the color along with the amount of parameters consumed
+func parseExtendedColor(params []int) (TermColor, int) {
+	if len(params) >= 2 && params[0] == 5 {
+		return TermColor{Mode: Color256, Index: uint8(params[1])}, 2
+	}
+	if len(params) >= 4 && params[0] == 2 {
+		return TermColor{Mode: ColorRGB, R: uint8(params[1]), G: uint8(params[2]), B: uint8(params[3])}, 4
+	}
+	return TermColor{}, len(params)
+}
+
+// Apply updates the state using the parameters of a single SGR sequence
+func (state *SGRState) Apply(params string) {
+	values := parseSGRParams(params)
+	for i := 0; i < len(values); i++ {
+		value := values[i]
+		switch {
+		case value == 0:
+			*state = SGRState{}
+		case value == 1:
+			state.Bold = true
+		case value == 2:
+			state.Dim = true
+		case value == 3:
+			state.Italic = true
+		case value == 4:
+			state.Underline = true
+		case value == 5 || value == 6:
+			state.Blink = true
+		case value == 7:
+			state.Reverse = true
+		case value == 8:
+			state.Hidden = true
+		case value == 9:
+			state.Strikethrough = true
+		case value == 22:
+			state.Bold = false
+			state.Dim = false
+		case value == 23:
+			state.Italic = false
+		case value == 24:
+			state.Underline = false
+		case value == 25:
+			state.Blink = false
+		case value == 27:
+			state.Reverse = false
+		case value == 28:
+			state.Hidden = false
+		case value == 29:
+			state.Strikethrough = false
+		case value >= 30 && value <= 37:
+			state.Foreground = TermColor{Mode: Color16, Index: uint8(value - 30)}
+		case value == 38:
+			color, consumed := parseExtendedColor(values[i+1:])
+			state.Foreground = color
+			i += consumed
+		case value == 39:
+			state.Foreground = TermColor{}
+		case value >= 40 && value <= 47:
+			state.Background = TermColor{Mode: Color16, Index: uint8(value - 40)}
+		case value == 48:
+			color, consumed := parseExtendedColor(values[i+1:])
+			state.Background = color
+			i += consumed
+		case value == 49:
+			state.Background = TermColor{}
+		case value >= 90 && value <= 97:
+			state.Foreground = TermColor{Mode: Color16, Index: uint8(value - 90 + 8)}
+		case value >= 100 && value <= 107:
+			state.Background = TermColor{Mode: Color16, Index: uint8(value - 100 + 8)}
+		}
+	}
+}
+
+// Scan applies every SGR sequence found in a string to the state
+func (state *SGRState) Scan(str string) {
+	for _, match := range sgrRegex.FindAllStringSubmatch(str, -1) {
+		state.Apply(match[1])
+	}
+}
+
+func (color TermColor) params(background bool) []string {
+	base := 30
+	if background {
+		base = 40
+	}
+	switch color.Mode {
+	case Color16:
+		if color.Index < 8 {
+			return []string{strconv.Itoa(base + int(color.Index))}
+		}
+		return []string{strconv.Itoa(base + 60 + int(color.Index) - 8)}
+	case Color256:
+		return []string{strconv.Itoa(base + 8), "5", strconv.Itoa(int(color.Index))}
+	case ColorRGB:
+		return []string{strconv.Itoa(base + 8), "2", strconv.Itoa(int(color.R)), strconv.Itoa(int(color.G)), strconv.Itoa(int(color.B))}
+	}
+	return nil
+}
+
+// Sequence returns an SGR sequence resetting the terminal and then restoring this state
+func (state SGRState) Sequence() string {
+	params := []string{"0"}
+	attributes := []struct {
+		set   bool
+		param string
+	}{
+		{state.Bold, "1"}, {state.Dim, "2"}, {state.Italic, "3"}, {state.Underline, "4"},
+		{state.Blink, "5"}, {state.Reverse, "7"}, {state.Hidden, "8"}, {state.Strikethrough, "9"},
+	}
+	for _, attribute := range attributes {
+		if attribute.set {
+			params = append(params, attribute.param)
+		}
+	}
+	params = append(params, state.Foreground.params(false)...)
+	params = append(params, state.Background.params(true)...)
+	return "\033[" + strings.Join(params, ";") + "m"
+}
@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+func TestSGRStateApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial SGRState
+		params  string
+		want    SGRState
+	}{
+		{"empty resets", SGRState{Bold: true, Foreground: TermColor{Mode: Color16, Index: 1}}, "", SGRState{}},
+		{"zero resets", SGRState{Italic: true}, "0", SGRState{}},
+		{"attributes", SGRState{}, "1;2;3;4;5;7;8;9", SGRState{Bold: true, Dim: true, Italic: true, Underline: true, Blink: true, Reverse: true, Hidden: true, Strikethrough: true}},
+		{"22 clears bold and dim", SGRState{Bold: true, Dim: true, Italic: true}, "22", SGRState{Italic: true}},
+		{"attribute resets", SGRState{Italic: true, Underline: true, Blink: true, Reverse: true, Hidden: true, Strikethrough: true}, "23;24;25;27;28;29", SGRState{}},
+		{"basic colors", SGRState{}, "31;42", SGRState{Foreground: TermColor{Mode: Color16, Index: 1}, Background: TermColor{Mode: Color16, Index: 2}}},
+		{"bright colors", SGRState{}, "97;100", SGRState{Foreground: TermColor{Mode: Color16, Index: 15}, Background: TermColor{Mode: Color16, Index: 8}}},
+		{"256 colors", SGRState{}, "38;5;208;48;5;17", SGRState{Foreground: TermColor{Mode: Color256, Index: 208}, Background: TermColor{Mode: Color256, Index: 17}}},
+		{"truecolor", SGRState{}, "38;2;10;20;30", SGRState{Foreground: TermColor{Mode: ColorRGB, R: 10, G: 20, B: 30}}},
+		{"truecolor followed by attribute", SGRState{}, "48;2;1;2;3;1", SGRState{Bold: true, Background: TermColor{Mode: ColorRGB, R: 1, G: 2, B: 3}}},
+		{"colon sub-parameters", SGRState{}, "38:2:1:2:3", SGRState{Foreground: TermColor{Mode: ColorRGB, R: 1, G: 2, B: 3}}},
+		{"colon sub-parameters with color space", SGRState{}, "38:2::1:2:3", SGRState{Foreground: TermColor{Mode: ColorRGB, R: 1, G: 2, B: 3}}},
+		{"default colors", SGRState{Bold: true, Foreground: TermColor{Mode: Color16, Index: 1}, Background: TermColor{Mode: Color16, Index: 2}}, "39;49", SGRState{Bold: true}},
+		{"truncated extended color", SGRState{Bold: true}, "38;5", SGRState{Bold: true}},
+		{"unknown parameters are ignored", SGRState{Underline: true}, "60;65", SGRState{Underline: true}},
+		{"state is kept across sequences", SGRState{Bold: true}, "34", SGRState{Bold: true, Foreground: TermColor{Mode: Color16, Index: 4}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := test.initial
+			state.Apply(test.params)
+			if state != test.want {
+				t.Errorf("Apply(%q) = %+v, want %+v", test.params, state, test.want)
+			}
+		})
+	}
+}
+
+func TestSGRStateSequence(t *testing.T) {
+	tests := []SGRState{
+		{},
+		{Bold: true, Underline: true},
+		{Dim: true, Reverse: true, Hidden: true, Strikethrough: true},
+		{Foreground: TermColor{Mode: Color16, Index: 3}, Background: TermColor{Mode: Color16, Index: 12}},
+		{Italic: true, Foreground: TermColor{Mode: Color256, Index: 200}, Background: TermColor{Mode: ColorRGB, R: 1, G: 2, B: 3}},
+	}
+	for _, want := range tests {
+		// The sequence of a state applied to any state must restore it
+		state := SGRState{Blink: true, Foreground: TermColor{Mode: Color16, Index: 5}}
+		state.Scan(want.Sequence())
+		if state != want {
+			t.Errorf("Scan(%q) = %+v, want %+v", want.Sequence(), state, want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"text/tabwriter"
+)
+
+var validateAll = false
+var validateJSON = false
+var validateMaxWidth = 60
+var validateMaxHeight = 30
+
+// loadAsciiArt reads an ascii art given either its ID or a path to a file, returning its path or "embedded:ID" along with its content
+func loadAsciiArt(arg string) (string, string, error) {
+	name := arg
+	if stat, err := os.Stat(arg); err != nil || stat.IsDir() {
+		name = GetAsciiArtPath(arg)
+		if name == "" {
+			name = "embedded:" + arg
+		}
+		ascii, err := ReadAsciiArt(arg)
+		return name, ascii, err
+	}
+	bytes, err := os.ReadFile(arg)
+	return name, string(bytes), err
+}
+
+func defaultAsciiID() string {
+	if config.Ascii == "auto" {
+		id, _ := ResolveAsciiArtID(GetDistroInfo())
+		return id
+	}
+	return config.Ascii
+}
+
+func runAsciiList(args []string) int {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, source := range ListAsciiArtSources() {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", source.ID, source.Source, source.Path)
+	}
+	if err := writer.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+func runAsciiShow(args []string) int {
+	id := defaultAsciiID()
+	if len(args) > 0 {
+		id = args[0]
+	}
+	asciiPath, content, err := loadAsciiArt(id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	ascii, _, err := PrepareAscii(content)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s: %s\n", asciiPath, err)
+		return 1
+	}
+	frames := SplitAsciiFrames(ascii)
+	ascii = frames[len(frames)-1]
+	if ColorEnabled() {
+		ascii += "\033[0m"
+	}
+	fmt.Println(ascii)
+	return 0
+}
+
+// galleryGap is the amount of spaces between the arts of the gallery
+const galleryGap = 4
+
+func runAsciiGallery(args []string) int {
+	// Show every art with the colors of its own header
+	config.ForceConfigAnsii = false
+	config.Theme = ""
+	columns := 80
+	if size, ok := GetTerminalSize(); ok {
+		columns = size.Columns
+	}
+
+	var blocks [][]string
+	var widths []int
+	for _, source := range ListAsciiArtSources() {
+		content, err := ReadAsciiArt(source.ID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			continue
+		}
+		config.AnsiiColors = []string{}
+		ascii, _, err := PrepareAscii(strings.TrimRight(content, "\n\t "))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %s\n", source.ID, err)
+			continue
+		}
+		frames := SplitAsciiFrames(ascii)
+		ascii = frames[len(frames)-1]
+		label := source.ID
+		if ColorEnabled() {
+			label = "\033[1m" + label + "\033[0m"
+		}
+		lines, width := renderBlock(label+"\n"+ascii, true)
+		blocks = append(blocks, lines)
+		widths = append(widths, width)
+	}
+
+	// Fill each row with as many arts as the terminal width allows
+	output := ""
+	for start := 0; start < len(blocks); {
+		end, rowWidth := start+1, widths[start]
+		for end < len(blocks) && rowWidth+galleryGap+widths[end] <= columns {
+			rowWidth += galleryGap + widths[end]
+			end++
+		}
+		height := 0
+		for _, block := range blocks[start:end] {
+			height = max(height, len(block))
+		}
+		for lineIndex := 0; lineIndex < height; lineIndex++ {
+			line := ""
+			for i := start; i < end; i++ {
+				if i != start {
+					line += strings.Repeat(" ", galleryGap)
+				}
+				if lineIndex < len(blocks[i]) {
+					line += blocks[i][lineIndex]
+				} else {
+					line += strings.Repeat(" ", widths[i])
+				}
+			}
+			output += strings.TrimRight(line, " ") + "\n"
+		}
+		output += "\n"
+		start = end
+	}
+	if !ColorEnabled() {
+		output = StripAnsii(output)
+	}
+	fmt.Print(strings.TrimRight(output, "\n") + "\n")
+	return 0
+}
+
+func setupAsciiValidateFlags(flags *flag.FlagSet) {
+	flags.BoolVar(&validateAll, "all", false, "Check every ascii art file of the user and system config directories")
+	flags.BoolVar(&validateJSON, "json", false, "Print the problems found as a JSON array")
+	flags.IntVar(&validateMaxWidth, "max-width", validateMaxWidth, "Maximum width of an art in terminal cells")
+	flags.IntVar(&validateMaxHeight, "max-height", validateMaxHeight, "Maximum height of an art in lines")
+}
+
+func runAsciiValidate(args []string) int {
+	var files []string
+	if validateAll {
+		for _, dir := range GetAsciiArtDirs() {
+			entries, err := os.ReadDir(dir.Path)
+			if err != nil {
+				continue
+			}
+			for _, entry := range entries {
+				if !entry.IsDir() {
+					files = append(files, path.Join(dir.Path, entry.Name()))
+				}
+			}
+		}
+	} else if len(args) == 0 {
+		args = []string{defaultAsciiID()}
+	}
+	diagnostics := make([]AsciiDiagnostic, 0)
+	for _, arg := range append(files, args...) {
+		asciiPath, content, err := loadAsciiArt(arg)
+		if err != nil {
+			diagnostics = append(diagnostics, AsciiDiagnostic{File: arg, Severity: "error", Rule: "read", Message: err.Error()})
+			continue
+		}
+		fileDiagnostics := LintAscii(asciiPath, content, validateMaxWidth, validateMaxHeight)
+		if len(fileDiagnostics) == 0 && !validateJSON {
+			fmt.Printf("%s: ok\n", asciiPath)
+		}
+		diagnostics = append(diagnostics, fileDiagnostics...)
+	}
+
+	exitCode := 0
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == "error" {
+			exitCode = 1
+		}
+		if !validateJSON {
+			fmt.Println(diagnostic)
+		}
+	}
+	if validateJSON {
+		bytes, err := json.MarshalIndent(diagnostics, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			return 1
+		}
+		fmt.Println(string(bytes))
+	}
+	return exitCode
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+var benchIterations = 3
+
+func setupBenchFlags(flags *flag.FlagSet) {
+	flags.IntVar(&benchIterations, "iterations", 3, "Number of times to fetch every variable")
+}
+
+func runBench(args []string) int {
+	if benchIterations < 1 {
+		fmt.Fprintln(os.Stderr, "Error: iterations must be at least 1")
+		return 1
+	}
+	// Benchmarking should not pollute the usage history
+	config.EnableHistory = false
+
+	type timing struct {
+		min, max, total int64
+	}
+	var keys []string
+	timings := make(map[string]*timing)
+	record := func(key string, milliseconds int64) {
+		t, ok := timings[key]
+		if !ok {
+			t = &timing{min: milliseconds, max: milliseconds}
+			timings[key] = t
+			keys = append(keys, key)
+		}
+		t.min = min(t.min, milliseconds)
+		t.max = max(t.max, milliseconds)
+		t.total += milliseconds
+	}
+
+	colorMap, err := GetColorMap()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	for i := 0; i < benchIterations; i++ {
+		start := time.Now().UnixMilli()
+		if _, err := RunFetchScript(colorMap, record, nil); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			return 1
+		}
+		end := time.Now().UnixMilli()
+		record("Total", end-start)
+	}
+
+	fmt.Printf("%-24s %8s %8s %8s\n", "Variable", "Min (ms)", "Avg (ms)", "Max (ms)")
+	for _, key := range keys {
+		t := timings[key]
+		fmt.Printf("%-24s %8d %8d %8d\n", key, t.min, t.total/int64(benchIterations), t.max)
+	}
+	return 0
+}
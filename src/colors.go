@@ -0,0 +1,305 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var ColorDepths = []string{"auto", "truecolor", "256", "16"}
+var ColorModes = []string{"auto", "always", "never"}
+
+// basicPalette holds the RGB values xterm uses for the 16 basic colors
+var basicPalette = [16][3]uint8{
+	{0x00, 0x00, 0x00}, {0xcd, 0x00, 0x00}, {0x00, 0xcd, 0x00}, {0xcd, 0xcd, 0x00},
+	{0x00, 0x00, 0xee}, {0xcd, 0x00, 0xcd}, {0x00, 0xcd, 0xcd}, {0xe5, 0xe5, 0xe5},
+	{0x7f, 0x7f, 0x7f}, {0xff, 0x00, 0x00}, {0x00, 0xff, 0x00}, {0xff, 0xff, 0x00},
+	{0x5c, 0x5c, 0xff}, {0xff, 0x00, 0xff}, {0x00, 0xff, 0xff}, {0xff, 0xff, 0xff},
+}
+
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+var colorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// RGB returns the RGB value of a color using the xterm palette
+func (color TermColor) RGB() (uint8, uint8, uint8) {
+	switch color.Mode {
+	case ColorRGB:
+		return color.R, color.G, color.B
+	case Color16:
+		rgb := basicPalette[color.Index%16]
+		return rgb[0], rgb[1], rgb[2]
+	case Color256:
+		switch {
+		case color.Index < 16:
+			rgb := basicPalette[color.Index]
+			return rgb[0], rgb[1], rgb[2]
+		case color.Index < 232:
+			index := color.Index - 16
+			return cubeLevels[index/36], cubeLevels[index/6%6], cubeLevels[index%6]
+		default:
+			gray := 8 + (color.Index-232)*10
+			return gray, gray, gray
+		}
+	}
+	return 0, 0, 0
+}
+
+func colorDistance(r1, g1, b1, r2, g2, b2 uint8) int {
+	dr, dg, db := int(r1)-int(r2), int(g1)-int(g2), int(b1)-int(b2)
+	return dr*dr + dg*dg + db*db
+}
+
+// nearestCubeLevel returns the index of the 256-color cube level closest to a color component
+func nearestCubeLevel(value uint8) int {
+	best := 0
+	for i, level := range cubeLevels {
+		if absInt(int(level)-int(value)) < absInt(int(cubeLevels[best])-int(value)) {
+			best = i
+		}
+	}
+	return best
+}
+
+func absInt(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// Downgrade converts a color to the closest one the given mode can display
+func (color TermColor) Downgrade(mode ColorMode) TermColor {
+	if color.Mode == ColorDefault || color.Mode <= mode {
+		return color
+	}
+	r, g, b := color.RGB()
+	if mode == Color256 {
+		// Pick the closest of the 6x6x6 cube and the grayscale ramp
+		cr, cg, cb := nearestCubeLevel(r), nearestCubeLevel(g), nearestCubeLevel(b)
+		cube := TermColor{Mode: Color256, Index: uint8(16 + cr*36 + cg*6 + cb)}
+		grayLevel := min(max((int(r)+int(g)+int(b))/3-8+5, 0)/10, 23)
+		gray := TermColor{Mode: Color256, Index: uint8(232 + grayLevel)}
+		cubeR, cubeG, cubeB := cube.RGB()
+		grayR, grayG, grayB := gray.RGB()
+		if colorDistance(r, g, b, grayR, grayG, grayB) < colorDistance(r, g, b, cubeR, cubeG, cubeB) {
+			return gray
+		}
+		return cube
+	}
+	best := 0
+	for i, rgb := range basicPalette {
+		if colorDistance(r, g, b, rgb[0], rgb[1], rgb[2]) < colorDistance(r, g, b, basicPalette[best][0], basicPalette[best][1], basicPalette[best][2]) {
+			best = i
+		}
+	}
+	return TermColor{Mode: Color16, Index: uint8(best)}
+}
+
+// Downgrade converts the colors of a state to the closest ones the given mode can display
+func (state SGRState) Downgrade(mode ColorMode) SGRState {
+	state.Foreground = state.Foreground.Downgrade(mode)
+	state.Background = state.Background.Downgrade(mode)
+	return state
+}
+
+// GetColorDepth returns the color mode supported by the terminal, detected from COLORTERM and TERM unless set in the config
+func GetColorDepth() ColorMode {
+	switch config.ColorDepth {
+	case "truecolor":
+		return ColorRGB
+	case "256":
+		return Color256
+	case "16":
+		return Color16
+	}
+	term := os.Getenv("TERM")
+	switch {
+	case os.Getenv("COLORTERM") == "truecolor" || os.Getenv("COLORTERM") == "24bit" || strings.HasSuffix(term, "-direct"):
+		return ColorRGB
+	case strings.Contains(term, "256color"):
+		return Color256
+	case strings.Contains(term, "16color") || term == "linux" || term == "ansi" || term == "cons25" || strings.HasPrefix(term, "vt"):
+		return Color16
+	}
+	return Color256
+}
+
+// ColorEnabled reports whether escape sequences should be written, following the color setting, NO_COLOR and whether stdout is a terminal
+func ColorEnabled() bool {
+	switch config.Color {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return IsTerminal(os.Stdout)
+}
+
+// GetColorBlocks renders the 8 basic colors of the terminal palette followed by their bright variants as rows of blocks
+func GetColorBlocks() string {
+	if !config.ShowColorBlocks {
+		return ""
+	}
+	var rows []string
+	for row := 0; row < min(config.ColorBlocksRows, 2); row++ {
+		line := ""
+		for i := 0; i < 8; i++ {
+			state := SGRState{Foreground: TermColor{Mode: Color16, Index: uint8(row*8 + i)}}
+			line += state.Sequence() + strings.Repeat(config.ColorBlocksGlyph, max(config.ColorBlocksWidth, 1))
+		}
+		rows = append(rows, line+"\033[0m")
+	}
+	return strings.Join(rows, "\n")
+}
+
+func parseColorComponent(str string) (uint8, error) {
+	value, err := strconv.ParseUint(strings.TrimSpace(str), 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid color component '%s'", str)
+	}
+	return uint8(value), nil
+}
+
+// ParseColor parses a single color: a 256-color index, '#rrggbb', '#rgb', 'rgb(r,g,b)', 'ansi(n)' or a name such as 'red' or 'bright-blue'
+func ParseColor(str string) (TermColor, error) {
+	str = strings.ToLower(str)
+	switch {
+	case str == "default":
+		return TermColor{}, nil
+	case strings.HasPrefix(str, "#"):
+		hex := str[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		value, err := strconv.ParseUint(hex, 16, 32)
+		if len(hex) != 6 || err != nil {
+			return TermColor{}, fmt.Errorf("invalid hex color '%s'", str)
+		}
+		return TermColor{Mode: ColorRGB, R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value)}, nil
+	case strings.HasPrefix(str, "rgb(") && strings.HasSuffix(str, ")"):
+		components := strings.Split(strings.TrimSuffix(strings.TrimPrefix(str, "rgb("), ")"), ",")
+		if len(components) != 3 {
+			return TermColor{}, fmt.Errorf("invalid rgb color '%s'", str)
+		}
+		color := TermColor{Mode: ColorRGB}
+		for i, component := range []*uint8{&color.R, &color.G, &color.B} {
+			value, err := parseColorComponent(components[i])
+			if err != nil {
+				return TermColor{}, err
+			}
+			*component = value
+		}
+		return color, nil
+	case strings.HasPrefix(str, "ansi(") && strings.HasSuffix(str, ")"):
+		index, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(str, "ansi("), ")"), 10, 8)
+		if err != nil || index > 15 {
+			return TermColor{}, fmt.Errorf("invalid 16-color index '%s'", str)
+		}
+		return TermColor{Mode: Color16, Index: uint8(index)}, nil
+	}
+	if index, err := strconv.ParseUint(str, 10, 8); err == nil {
+		return TermColor{Mode: Color256, Index: uint8(index)}, nil
+	}
+	name := str
+	bright := false
+	if strings.HasPrefix(name, "bright-") {
+		name = strings.TrimPrefix(name, "bright-")
+		bright = true
+	}
+	if name == "gray" || name == "grey" {
+		return TermColor{Mode: Color16, Index: 8}, nil
+	}
+	for i, colorName := range colorNames {
+		if name == colorName {
+			if bright {
+				i += 8
+			}
+			return TermColor{Mode: Color16, Index: uint8(i)}, nil
+		}
+	}
+	return TermColor{}, fmt.Errorf("unknown color '%s'", str)
+}
+
+// ParseColorSpec parses a color slot made of whitespace separated words: a foreground color, a background color prefixed with 'bg:'
+// and the attributes bold, dim, italic and underline. A lone 256-color index is rendered bold for compatibility with older configs
+func ParseColorSpec(spec string) (SGRState, error) {
+	state := SGRState{}
+	words := strings.Fields(spec)
+	if len(words) == 1 {
+		if _, err := strconv.ParseUint(words[0], 10, 8); err == nil {
+			state.Bold = true
+		}
+	}
+	for _, word := range words {
+		var err error
+		lower := strings.ToLower(word)
+		switch lower {
+		case "bold":
+			state.Bold = true
+		case "dim":
+			state.Dim = true
+		case "italic":
+			state.Italic = true
+		case "underline":
+			state.Underline = true
+		default:
+			if strings.HasPrefix(lower, "bg:") {
+				state.Background, err = ParseColor(word[len("bg:"):])
+			} else if strings.HasPrefix(lower, "fg:") {
+				state.Foreground, err = ParseColor(word[len("fg:"):])
+			} else {
+				state.Foreground, err = ParseColor(word)
+			}
+		}
+		if err != nil {
+			return state, err
+		}
+	}
+	return state, nil
+}
+
+func formatColor(color TermColor) string {
+	switch color.Mode {
+	case Color16:
+		if color.Index >= 8 {
+			return "bright-" + colorNames[color.Index%8]
+		}
+		return colorNames[color.Index]
+	case Color256, ColorRGB:
+		// A lone 256-color index would be rendered bold so indexes are written as hex colors as well
+		r, g, b := color.RGB()
+		return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+	}
+	return ""
+}
+
+// FormatColorSpec returns the color slot describing a state, the reverse of ParseColorSpec
+func FormatColorSpec(state SGRState) string {
+	var words []string
+	if foreground := formatColor(state.Foreground); foreground != "" {
+		words = append(words, foreground)
+	}
+	if background := formatColor(state.Background); background != "" {
+		words = append(words, "bg:"+background)
+	}
+	attributes := []struct {
+		set  bool
+		word string
+	}{
+		{state.Bold, "bold"}, {state.Dim, "dim"}, {state.Italic, "italic"}, {state.Underline, "underline"},
+	}
+	for _, attribute := range attributes {
+		if attribute.set {
+			words = append(words, attribute.word)
+		}
+	}
+	if len(words) == 0 {
+		return "default"
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,125 @@
+package main
+
+import "testing"
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    TermColor
+		wantErr bool
+	}{
+		{"default", TermColor{}, false},
+		{"red", TermColor{Mode: Color16, Index: 1}, false},
+		{"Bright-Blue", TermColor{Mode: Color16, Index: 12}, false},
+		{"grey", TermColor{Mode: Color16, Index: 8}, false},
+		{"ansi(14)", TermColor{Mode: Color16, Index: 14}, false},
+		{"208", TermColor{Mode: Color256, Index: 208}, false},
+		{"#FF8000", TermColor{Mode: ColorRGB, R: 0xff, G: 0x80, B: 0x00}, false},
+		{"#f80", TermColor{Mode: ColorRGB, R: 0xff, G: 0x88, B: 0x00}, false},
+		{"rgb(1, 2, 3)", TermColor{Mode: ColorRGB, R: 1, G: 2, B: 3}, false},
+		{"ansi(16)", TermColor{}, true},
+		{"256", TermColor{}, true},
+		{"#12345", TermColor{}, true},
+		{"#ggg", TermColor{}, true},
+		{"rgb(1,2)", TermColor{}, true},
+		{"rgb(1,2,300)", TermColor{}, true},
+		{"bright-gray", TermColor{Mode: Color16, Index: 8}, false},
+		{"purple", TermColor{}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.str, func(t *testing.T) {
+			color, err := ParseColor(test.str)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseColor(%q) error = %v, want error %t", test.str, err, test.wantErr)
+			}
+			if err == nil && color != test.want {
+				t.Errorf("ParseColor(%q) = %+v, want %+v", test.str, color, test.want)
+			}
+		})
+	}
+}
+
+func TestParseColorSpec(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    SGRState
+		wantErr bool
+	}{
+		{"", SGRState{}, false},
+		{"red", SGRState{Foreground: TermColor{Mode: Color16, Index: 1}}, false},
+		{"fg:red", SGRState{Foreground: TermColor{Mode: Color16, Index: 1}}, false},
+		{"Fg:red", SGRState{Foreground: TermColor{Mode: Color16, Index: 1}}, false},
+		{"bg:blue", SGRState{Background: TermColor{Mode: Color16, Index: 4}}, false},
+		{"BG:blue", SGRState{Background: TermColor{Mode: Color16, Index: 4}}, false},
+		{"#ffffff bg:#000000 bold", SGRState{Bold: true, Foreground: TermColor{Mode: ColorRGB, R: 0xff, G: 0xff, B: 0xff}, Background: TermColor{Mode: ColorRGB}}, false},
+		{"Bold DIM italic underline", SGRState{Bold: true, Dim: true, Italic: true, Underline: true}, false},
+		{"  cyan   italic  ", SGRState{Italic: true, Foreground: TermColor{Mode: Color16, Index: 6}}, false},
+		{"33", SGRState{Bold: true, Foreground: TermColor{Mode: Color256, Index: 33}}, false},
+		{"33 dim", SGRState{Dim: true, Foreground: TermColor{Mode: Color256, Index: 33}}, false},
+		{"bold", SGRState{Bold: true}, false},
+		{"bg:purple", SGRState{}, true},
+		{"red blinking", SGRState{}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			state, err := ParseColorSpec(test.spec)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseColorSpec(%q) error = %v, want error %t", test.spec, err, test.wantErr)
+			}
+			if err == nil && state != test.want {
+				t.Errorf("ParseColorSpec(%q) = %+v, want %+v", test.spec, state, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatColorSpec(t *testing.T) {
+	for _, spec := range []string{"default", "red", "bright-white bg:blue bold", "#ff8000 italic underline", "bg:#080808 dim"} {
+		state, err := ParseColorSpec(spec)
+		if err != nil {
+			t.Fatalf("ParseColorSpec(%q) error = %v", spec, err)
+		}
+		if got := FormatColorSpec(state); got != spec {
+			t.Errorf("FormatColorSpec(ParseColorSpec(%q)) = %q", spec, got)
+		}
+	}
+	// A lone index is rendered bold so it has to be written as a hex color to keep its meaning
+	if got := FormatColorSpec(SGRState{Foreground: TermColor{Mode: Color256, Index: 196}}); got != "#ff0000" {
+		t.Errorf("FormatColorSpec() of index 196 = %q, want %q", got, "#ff0000")
+	}
+}
+
+func TestTermColorDowngrade(t *testing.T) {
+	tests := []struct {
+		name  string
+		color TermColor
+		mode  ColorMode
+		want  TermColor
+	}{
+		{"default is kept", TermColor{}, Color16, TermColor{}},
+		{"supported mode is kept", TermColor{Mode: Color16, Index: 3}, Color256, TermColor{Mode: Color16, Index: 3}},
+		{"truecolor is kept", TermColor{Mode: ColorRGB, R: 1, G: 2, B: 3}, ColorRGB, TermColor{Mode: ColorRGB, R: 1, G: 2, B: 3}},
+		{"rgb to cube", TermColor{Mode: ColorRGB, R: 0xff}, Color256, TermColor{Mode: Color256, Index: 196}},
+		{"rgb to nearest cube level", TermColor{Mode: ColorRGB, R: 0xd0, G: 0x60, B: 0x10}, Color256, TermColor{Mode: Color256, Index: 166}},
+		{"rgb to grayscale", TermColor{Mode: ColorRGB, R: 0x80, G: 0x80, B: 0x80}, Color256, TermColor{Mode: Color256, Index: 244}},
+		{"rgb to basic", TermColor{Mode: ColorRGB, R: 0xff}, Color16, TermColor{Mode: Color16, Index: 9}},
+		{"rgb to nearest basic", TermColor{Mode: ColorRGB, R: 0x10, G: 0xc0, B: 0x10}, Color16, TermColor{Mode: Color16, Index: 2}},
+		{"256 to basic", TermColor{Mode: Color256, Index: 1}, Color16, TermColor{Mode: Color16, Index: 1}},
+		{"256 grayscale to basic", TermColor{Mode: Color256, Index: 255}, Color16, TermColor{Mode: Color16, Index: 7}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.color.Downgrade(test.mode); got != test.want {
+				t.Errorf("%+v.Downgrade(%d) = %+v, want %+v", test.color, test.mode, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSGRStateDowngrade(t *testing.T) {
+	state := SGRState{Bold: true, Foreground: TermColor{Mode: ColorRGB, R: 0xff}, Background: TermColor{Mode: Color256, Index: 21}}
+	want := SGRState{Bold: true, Foreground: TermColor{Mode: Color16, Index: 9}, Background: TermColor{Mode: Color16, Index: 4}}
+	if got := state.Downgrade(Color16); got != want {
+		t.Errorf("Downgrade(Color16) = %+v, want %+v", got, want)
+	}
+}
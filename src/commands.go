@@ -0,0 +1,282 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"log"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+)
+
+var version = "dev"
+
+type Command struct {
+	Name           string
+	Usage          string
+	Description    string
+	Hidden         bool
+	RequiresConfig bool
+	// ArgsCompleter is the key of the FlagCompleters function completing the positional arguments
+	ArgsCompleter string
+	Subcommands   []Command
+	SetupFlags    func(flags *flag.FlagSet)
+	Run           func(args []string) int
+}
+
+var Commands []Command
+
+func init() {
+	Commands = []Command{
+		{
+			Name:        "ascii",
+			Description: "Manage distribution ascii art",
+			Subcommands: []Command{
+				{
+					Name:        "list",
+					Description: "List available ascii art along with the directory they are read from",
+					Run:         runAsciiList,
+				},
+				{
+					Name:        "gallery",
+					Description: "Preview every available ascii art in a grid",
+					Run:         runAsciiGallery,
+				},
+				{
+					Name:          "show",
+					Usage:         "[ID]",
+					Description:   "Print an ascii art with its colors applied",
+					ArgsCompleter: "ascii",
+					Run:           runAsciiShow,
+				},
+				{
+					Name:        "convert",
+					Usage:       "IMAGE",
+					Description: "Convert a PNG or JPEG image into an ascii art file",
+					SetupFlags:  setupAsciiConvertFlags,
+					Run:         runAsciiConvert,
+				},
+				{
+					Name:        "import",
+					Usage:       "FILE",
+					Description: "Convert neofetch or fastfetch ascii art into the stormfetch format",
+					SetupFlags:  setupAsciiImportFlags,
+					Run:         runAsciiImport,
+				},
+				{
+					Name:          "validate",
+					Usage:         "[ID|FILE]...",
+					Description:   "Check ascii art files for errors",
+					ArgsCompleter: "ascii",
+					SetupFlags:    setupAsciiValidateFlags,
+					Run:           runAsciiValidate,
+				},
+			},
+		},
+		{
+			Name:           "config",
+			Description:    "Print the configuration file paths and the effective configuration",
+			RequiresConfig: true,
+			Run:            runConfig,
+		},
+		{
+			Name:           "vars",
+			Description:    "Print the variables passed to the fetch script",
+			RequiresConfig: true,
+			Run:            runVars,
+		},
+		{
+			Name:        "doctor",
+			Description: "Check the configuration files, ascii art and required programs",
+			Run: func(args []string) int {
+				return runDoctor(configErr)
+			},
+		},
+		{
+			Name:           "snapshot",
+			Description:    "Print a redacted report of the system information and configuration for bug reports",
+			RequiresConfig: true,
+			SetupFlags:     setupSnapshotFlags,
+			Run:            runSnapshot,
+		},
+		{
+			Name:           "bench",
+			Description:    "Measure the time taken to fetch each variable",
+			RequiresConfig: true,
+			SetupFlags:     setupBenchFlags,
+			Run:            runBench,
+		},
+		{
+			Name:          "completion",
+			Usage:         "bash|zsh|fish",
+			Description:   "Print a shell completion script",
+			ArgsCompleter: "shell",
+			Run: func(args []string) int {
+				if len(args) != 1 {
+					fmt.Fprintln(os.Stderr, "Usage: stormfetch completion bash|zsh|fish")
+					return 1
+				}
+				script, err := GenerateCompletion(args[0])
+				if err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					return 1
+				}
+				fmt.Print(script)
+				return 0
+			},
+		},
+		{
+			Name:        "man",
+			Description: "Print the manual page in roff format",
+			Run: func(args []string) int {
+				fmt.Print(GenerateManPage())
+				return 0
+			},
+		},
+		{
+			Name:   "__complete",
+			Usage:  "FLAG",
+			Hidden: true,
+			Run: func(args []string) int {
+				if len(args) != 1 {
+					return 1
+				}
+				if completer, ok := FlagCompleters[args[0]]; ok {
+					fmt.Println(strings.Join(completer(), "\n"))
+				}
+				return 0
+			},
+		},
+	}
+}
+
+// NewCommandFlagSet returns a flag set containing the global flags along with the flags of the given command
+func NewCommandFlagSet(command Command, commandPath []string) *flag.FlagSet {
+	flags := flag.NewFlagSet("stormfetch "+strings.Join(commandPath, " "), flag.ExitOnError)
+	addGlobalFlags(flags)
+	if command.SetupFlags != nil {
+		command.SetupFlags(flags)
+	}
+	flags.Usage = func() {
+		printUsage(flags, commandPath, command.Subcommands)
+	}
+	return flags
+}
+
+// parseFlags parses flags placed before, between and after the positional arguments, which are returned
+func parseFlags(flags *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		_ = flags.Parse(args)
+		remaining := flags.Args()
+		// Everything following "--" is a positional argument
+		if consumed := len(args) - len(remaining); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, remaining...)
+		}
+		if len(remaining) == 0 {
+			return positional
+		}
+		positional = append(positional, remaining[0])
+		args = remaining[1:]
+	}
+}
+
+func runCommand(commands []Command, parents []string, args []string) {
+	for _, command := range commands {
+		if command.Name != args[0] {
+			continue
+		}
+		commandPath := append(slices.Clone(parents), command.Name)
+		if len(command.Subcommands) != 0 {
+			if len(args) < 2 || args[1] == "-h" || args[1] == "--help" {
+				printUsage(NewCommandFlagSet(command, commandPath), commandPath, command.Subcommands)
+				os.Exit(2)
+			}
+			runCommand(command.Subcommands, commandPath, args[1:])
+			return
+		}
+		flags := NewCommandFlagSet(command, commandPath)
+		positional := parseFlags(flags, args[1:])
+		if command.RequiresConfig && configErr != nil {
+			log.Fatal(configErr)
+		}
+		os.Exit(command.Run(positional))
+	}
+	log.Fatalf("Unknown command: %s", strings.Join(append(slices.Clone(parents), args[0]), " "))
+}
+
+func printUsage(flags *flag.FlagSet, commandPath []string, subcommands []Command) {
+	usage := strings.TrimSpace("stormfetch " + strings.Join(commandPath, " "))
+	if len(subcommands) != 0 {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options] [command]\n\nCommands:\n", usage)
+		var walk func(commands []Command, prefix string)
+		walk = func(commands []Command, prefix string) {
+			for _, command := range commands {
+				if command.Hidden {
+					continue
+				}
+				if len(command.Subcommands) != 0 {
+					walk(command.Subcommands, prefix+command.Name+" ")
+					continue
+				}
+				fmt.Fprintf(os.Stderr, "  %-28s %s\n", strings.TrimSpace(prefix+command.Name+" "+command.Usage), command.Description)
+			}
+		}
+		walk(subcommands, "")
+	} else {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n", usage)
+	}
+	fmt.Fprintf(os.Stderr, "\nOptions:\n")
+	flags.SetOutput(os.Stderr)
+	flags.PrintDefaults()
+}
+
+func printVersion() {
+	fmt.Printf("stormfetch %s\n", version)
+	fmt.Printf("System config directory: %s\n", systemConfigDir)
+	fmt.Printf("Go version: %s\n", runtime.Version())
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				fmt.Printf("Revision: %s\n", setting.Value)
+			}
+		}
+	}
+}
+
+func runConfig(args []string) int {
+	fmt.Printf("# Config file: %s\n", configPath)
+	fmt.Printf("# Fetch script: %s\n", fetchScriptPath)
+	bytes, err := yaml.Marshal(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not encode config: %s\n", err)
+		return 1
+	}
+	fmt.Print(string(bytes))
+	return 0
+}
+
+func runVars(args []string) int {
+	var redactor *Redactor
+	if config.Redact {
+		var err error
+		redactor, err = NewRedactor()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not setup redaction: %s\n", err)
+			return 1
+		}
+	}
+	env := SetupFetchEnv(nil, redactor)
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fmt.Printf("%s=%s\n", key, env[key])
+	}
+	return 0
+}
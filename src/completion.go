@@ -0,0 +1,450 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// FlagCompleters holds functions returning the possible values of flags and command arguments, used by the generated completion scripts
+var FlagCompleters = map[string]func() []string{
+	"ascii": func() []string {
+		return append([]string{"auto"}, ListAsciiArts()...)
+	},
+	"ascii-size": func() []string {
+		return AsciiSizes
+	},
+	"ascii-position": func() []string {
+		return AsciiPositions
+	},
+	"color": func() []string {
+		return ColorModes
+	},
+	"image-protocol": func() []string {
+		return ImageProtocols
+	},
+	"output": func() []string {
+		return OutputFormats
+	},
+	// Shared by the format flags of stormfetch and ascii import
+	"format": func() []string {
+		return append(slices.Clone(TextFormats), ImportFormats...)
+	},
+	"layout": func() []string {
+		return append([]string{"default"}, ListConfigFiles("layouts", ".sh")...)
+	},
+	"profile": func() []string {
+		return ListConfigFiles("profiles", ".yaml")
+	},
+	"mode": func() []string {
+		return ConvertModes
+	},
+	"theme": func() []string {
+		return append([]string{"none"}, ListThemes()...)
+	},
+	"vertical-align": func() []string {
+		return VerticalAlignments
+	},
+	"shell": func() []string {
+		return []string{"bash", "zsh", "fish"}
+	},
+}
+
+// completionNode describes the flags, subcommands and arguments accepted after a command path
+type completionNode struct {
+	Path          string
+	Subcommands   []Command
+	Flags         []*flag.Flag
+	ArgsCompleter string
+}
+
+func isBoolFlag(f *flag.Flag) bool {
+	boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && boolFlag.IsBoolFlag()
+}
+
+func visibleCommands(commands []Command) (ret []Command) {
+	for _, command := range commands {
+		if !command.Hidden {
+			ret = append(ret, command)
+		}
+	}
+	return ret
+}
+
+func collectFlags(flags *flag.FlagSet) (ret []*flag.Flag) {
+	flags.VisitAll(func(f *flag.Flag) {
+		ret = append(ret, f)
+	})
+	return ret
+}
+
+func completionNodes() []completionNode {
+	nodes := []completionNode{{
+		Path:        "",
+		Subcommands: visibleCommands(Commands),
+		Flags:       collectFlags(flag.CommandLine),
+	}}
+	var walk func(commands []Command, parents []string)
+	walk = func(commands []Command, parents []string) {
+		for _, command := range visibleCommands(commands) {
+			commandPath := append(slices.Clone(parents), command.Name)
+			nodes = append(nodes, completionNode{
+				Path:          strings.Join(commandPath, " "),
+				Subcommands:   visibleCommands(command.Subcommands),
+				Flags:         collectFlags(NewCommandFlagSet(command, commandPath)),
+				ArgsCompleter: command.ArgsCompleter,
+			})
+			walk(command.Subcommands, commandPath)
+		}
+	}
+	walk(Commands, nil)
+	return nodes
+}
+
+// valueFlags returns the names of all flags taking a value along with their completer
+func valueFlags(nodes []completionNode) (names []string, completers map[string]string) {
+	completers = make(map[string]string)
+	for _, node := range nodes {
+		for _, f := range node.Flags {
+			if isBoolFlag(f) || slices.Contains(names, f.Name) {
+				continue
+			}
+			names = append(names, f.Name)
+			if _, ok := FlagCompleters[f.Name]; ok {
+				completers[f.Name] = f.Name
+			}
+		}
+	}
+	return names, completers
+}
+
+func GenerateCompletion(shell string) (string, error) {
+	switch shell {
+	case "bash":
+		return generateBashCompletion(), nil
+	case "zsh":
+		return generateZshCompletion(), nil
+	case "fish":
+		return generateFishCompletion(), nil
+	default:
+		return "", fmt.Errorf("unsupported shell '%s', expected bash, zsh or fish", shell)
+	}
+}
+
+func generateBashCompletion() string {
+	nodes := completionNodes()
+	names, completers := valueFlags(nodes)
+
+	var valueFlagPatterns, parentPatterns []string
+	valueCases := ""
+	for _, name := range names {
+		valueFlagPatterns = append(valueFlagPatterns, "--"+name, "-"+name)
+		if completer, ok := completers[name]; ok {
+			valueCases += fmt.Sprintf("        --%s|-%s)\n            COMPREPLY=($(compgen -W \"$(stormfetch __complete %s 2>/dev/null)\" -- \"$cur\"))\n            return\n            ;;\n", name, name, completer)
+		} else {
+			valueCases += fmt.Sprintf("        --%s|-%s)\n            return\n            ;;\n", name, name)
+		}
+	}
+	nodeCases := ""
+	for _, node := range nodes {
+		var subcommands, flags []string
+		for _, command := range node.Subcommands {
+			subcommands = append(subcommands, command.Name)
+		}
+		for _, f := range node.Flags {
+			flags = append(flags, "--"+f.Name)
+		}
+		if len(node.Subcommands) != 0 {
+			parentPatterns = append(parentPatterns, fmt.Sprintf("%q", node.Path))
+		}
+		nodeCases += fmt.Sprintf("        %q)\n            subcommands=%q\n            flags=%q\n            args=%q\n            ;;\n",
+			node.Path, strings.Join(subcommands, " "), strings.Join(flags, " "), node.ArgsCompleter)
+	}
+
+	return fmt.Sprintf(`# bash completion for stormfetch
+
+_stormfetch() {
+    local cur prev word command i
+    local subcommands="" flags="" args=""
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    # Find the command being completed, skipping flags and their values
+    command=""
+    for (( i = 1; i < COMP_CWORD; i++ )); do
+        word="${COMP_WORDS[i]}"
+        case "$word" in
+            %s)
+                (( i++ ))
+                continue
+                ;;
+            -*)
+                continue
+                ;;
+        esac
+        case "$command" in
+            %s)
+                command="${command:+$command }$word"
+                ;;
+        esac
+    done
+
+    case "$prev" in
+%s    esac
+
+    case "$command" in
+%s    esac
+
+    if [[ "$cur" == -* ]]; then
+        COMPREPLY=($(compgen -W "$flags" -- "$cur"))
+    elif [[ -n "$args" ]]; then
+        COMPREPLY=($(compgen -W "$(stormfetch __complete "$args" 2>/dev/null)" -- "$cur"))
+    else
+        COMPREPLY=($(compgen -W "$subcommands" -- "$cur"))
+    fi
+}
+
+complete -F _stormfetch stormfetch
+`, strings.Join(valueFlagPatterns, "|"), strings.Join(parentPatterns, "|"), valueCases, nodeCases)
+}
+
+func generateZshCompletion() string {
+	escape := func(str string) string {
+		return strings.NewReplacer("'", "'\\''", ":", "\\:").Replace(str)
+	}
+	nodes := completionNodes()
+	names, completers := valueFlags(nodes)
+
+	var valueFlagWords, parentPatterns []string
+	valueCases := ""
+	for _, name := range names {
+		valueFlagWords = append(valueFlagWords, "--"+name, "-"+name)
+		if completer, ok := completers[name]; ok {
+			valueCases += fmt.Sprintf("        --%s|-%s)\n            compadd -- ${(f)\"$(stormfetch __complete %s 2>/dev/null)\"}\n            return\n            ;;\n", name, name, completer)
+		} else {
+			valueCases += fmt.Sprintf("        --%s|-%s)\n            return\n            ;;\n", name, name)
+		}
+	}
+	nodeCases := ""
+	for _, node := range nodes {
+		subcommands := ""
+		for _, command := range node.Subcommands {
+			subcommands += fmt.Sprintf(" '%s:%s'", command.Name, escape(command.Description))
+		}
+		flags := ""
+		for _, f := range node.Flags {
+			flags += fmt.Sprintf(" '--%s:%s'", f.Name, escape(f.Usage))
+		}
+		if len(node.Subcommands) != 0 {
+			parentPatterns = append(parentPatterns, fmt.Sprintf("%q", node.Path))
+		}
+		nodeCases += fmt.Sprintf("        %q)\n            subcommands=(%s)\n            flags=(%s)\n            args=%q\n            ;;\n",
+			node.Path, subcommands, flags, node.ArgsCompleter)
+	}
+
+	return fmt.Sprintf(`#compdef stormfetch
+
+_stormfetch() {
+    local -a value_flags subcommands flags
+    local command="" args="" word i
+    value_flags=(%s)
+
+    # Find the command being completed, skipping flags and their values
+    for (( i = 2; i < CURRENT; i++ )); do
+        word="${words[i]}"
+        if (( ${value_flags[(Ie)$word]} )); then
+            (( i++ ))
+            continue
+        elif [[ "$word" == -* ]]; then
+            continue
+        fi
+        case "$command" in
+            %s)
+                command="${command:+$command }$word"
+                ;;
+        esac
+    done
+
+    case "${words[CURRENT-1]}" in
+%s    esac
+
+    case "$command" in
+%s    esac
+
+    if [[ "$PREFIX" == -* ]]; then
+        _describe 'option' flags
+    elif [[ -n "$args" ]]; then
+        compadd -- ${(f)"$(stormfetch __complete $args 2>/dev/null)"}
+    else
+        _describe 'command' subcommands
+    fi
+}
+
+_stormfetch "$@"
+`, strings.Join(valueFlagWords, " "), strings.Join(parentPatterns, "|"), valueCases, nodeCases)
+}
+
+func generateFishCompletion() string {
+	escape := func(str string) string {
+		return strings.NewReplacer("\\", "\\\\", "'", "\\'").Replace(str)
+	}
+	nodes := completionNodes()
+	names, completers := valueFlags(nodes)
+
+	var parents []string
+	for _, node := range nodes {
+		if len(node.Subcommands) != 0 {
+			parents = append(parents, fmt.Sprintf("'%s'", node.Path))
+		}
+	}
+	var valueFlagWords []string
+	for _, name := range names {
+		valueFlagWords = append(valueFlagWords, "--"+name, "-"+name)
+	}
+
+	ret := fmt.Sprintf(`# fish completion for stormfetch
+
+# Print the command being completed, skipping flags and their values
+function __stormfetch_command
+    set -l command
+    set -l skip 0
+    for token in (commandline -opc)[2..-1]
+        if test $skip -eq 1
+            set skip 0
+            continue
+        end
+        if contains -- $token %s
+            set skip 1
+            continue
+        end
+        if string match -q -- '-*' $token
+            continue
+        end
+        if contains -- (string join ' ' -- $command) %s
+            set -a command $token
+        end
+    end
+    string join ' ' -- $command
+end
+
+function __stormfetch_command_is
+    test (__stormfetch_command | string collect) = "$argv"
+end
+
+complete -c stormfetch -f
+`, strings.Join(valueFlagWords, " "), strings.Join(parents, " "))
+	for _, node := range nodes {
+		condition := fmt.Sprintf("__stormfetch_command_is %s", node.Path)
+		for _, command := range node.Subcommands {
+			ret += fmt.Sprintf("complete -c stormfetch -n '%s' -a %s -d '%s'\n", condition, command.Name, escape(command.Description))
+		}
+		if node.ArgsCompleter != "" {
+			ret += fmt.Sprintf("complete -c stormfetch -n '%s' -a '(stormfetch __complete %s 2>/dev/null)'\n", condition, node.ArgsCompleter)
+		}
+		for _, f := range node.Flags {
+			if isBoolFlag(f) {
+				ret += fmt.Sprintf("complete -c stormfetch -n '%s' -l %s -d '%s'\n", condition, f.Name, escape(f.Usage))
+			} else if completer, ok := completers[f.Name]; ok {
+				ret += fmt.Sprintf("complete -c stormfetch -n '%s' -l %s -d '%s' -r -a '(stormfetch __complete %s 2>/dev/null)'\n", condition, f.Name, escape(f.Usage), completer)
+			} else {
+				ret += fmt.Sprintf("complete -c stormfetch -n '%s' -l %s -d '%s' -r\n", condition, f.Name, escape(f.Usage))
+			}
+		}
+	}
+	return ret
+}
+
+func GenerateManPage() string {
+	escape := func(str string) string {
+		str = strings.NewReplacer("\\", "\\\\", "-", "\\-").Replace(str)
+		if strings.HasPrefix(str, ".") || strings.HasPrefix(str, "'") {
+			str = "\\&" + str
+		}
+		return str
+	}
+	writeFlags := func(flags []*flag.Flag) string {
+		ret := ""
+		for _, f := range flags {
+			if isBoolFlag(f) {
+				ret += fmt.Sprintf(".TP\n\\fB\\-\\-%s\\fR\n", escape(f.Name))
+			} else {
+				ret += fmt.Sprintf(".TP\n\\fB\\-\\-%s\\fR \\fI%s\\fR\n", escape(f.Name), escape(f.Name))
+			}
+			ret += escape(f.Usage) + "\n"
+			if f.DefValue != "" && !isBoolFlag(f) {
+				ret += fmt.Sprintf("(default: %s)\n", escape(f.DefValue))
+			}
+		}
+		return ret
+	}
+	// Document the built-in defaults rather than those of the config of whoever generates the page
+	savedConfig := config
+	config = DefaultConfig()
+	defer func() {
+		config = savedConfig
+	}()
+	rootFlags := flag.NewFlagSet("stormfetch", flag.ContinueOnError)
+	addRootFlags(rootFlags)
+	globalFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	addGlobalFlags(globalFlags)
+
+	ret := ".TH STORMFETCH 1 \"\" \"stormfetch\" \"User Commands\"\n"
+	ret += ".SH NAME\nstormfetch \\- a simple linux fetch program written in go and bash\n"
+	ret += ".SH SYNOPSIS\n.B stormfetch\n[\\fIOPTIONS\\fR] [\\fICOMMAND\\fR] [\\fIARGS\\fR]\n"
+	ret += ".SH DESCRIPTION\n"
+	ret += "Stormfetch reads your system's information and displays it in the terminal along with the ASCII art of the Linux distribution you are running. " +
+		"The displayed information is printed by a bash fetch script which receives the collected information through environment variables.\n"
+	ret += ".SH OPTIONS\n"
+	ret += "The following options are accepted by stormfetch and all of its commands.\n"
+	ret += writeFlags(collectFlags(rootFlags))
+	ret += ".SH COMMANDS\n"
+	for _, node := range completionNodes() {
+		if node.Path == "" || len(node.Subcommands) != 0 {
+			continue
+		}
+		var command Command
+		commands := Commands
+		for _, name := range strings.Split(node.Path, " ") {
+			for _, c := range commands {
+				if c.Name == name {
+					command = c
+					commands = c.Subcommands
+				}
+			}
+		}
+		ret += fmt.Sprintf(".TP\n\\fB%s\\fR", escape(node.Path))
+		if command.Usage != "" {
+			ret += fmt.Sprintf(" \\fI%s\\fR", escape(command.Usage))
+		}
+		ret += "\n" + escape(command.Description) + "\n"
+		var commandFlags []*flag.Flag
+		for _, f := range node.Flags {
+			if globalFlags.Lookup(f.Name) == nil {
+				commandFlags = append(commandFlags, f)
+			}
+		}
+		if len(commandFlags) != 0 {
+			ret += ".RS\n" + writeFlags(commandFlags) + ".RE\n"
+		}
+	}
+	ret += ".SH FILES\n"
+	for _, file := range []string{"config.yaml", "fetch_script.sh", "ascii/", "layouts/", "profiles/"} {
+		ret += fmt.Sprintf(".TP\n.I ~/.config/stormfetch/%s\n.TQ\n.I %s\n", escape(file), escape(path.Join(systemConfigDir, "stormfetch", file)))
+		switch file {
+		case "config.yaml":
+			ret += "Configuration file. The user file takes precedence over the system file.\n"
+		case "fetch_script.sh":
+			ret += "Bash script printing the displayed information.\n"
+		case "ascii/":
+			ret += "Directories containing distribution ASCII art.\n"
+		case "layouts/":
+			ret += "Directories containing fetch scripts selected with \\-\\-layout.\n"
+		case "profiles/":
+			ret += "Directories containing partial configuration files selected with \\-\\-profile.\n"
+		}
+	}
+	return ret
+}
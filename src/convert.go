@@ -0,0 +1,270 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ConvertModes = []string{"ascii", "blocks", "braille"}
+
+var convertWidth = 40
+var convertMode = "ascii"
+var convertOutput = ""
+var convertKeepBackground = false
+
+// asciiRamp holds the characters used by the ascii mode from the darkest to the brightest
+const asciiRamp = ".:-=+*#%@"
+
+// brailleDots holds the bit of each dot of a braille character indexed by [y][x]
+var brailleDots = [4][2]int{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+
+type convertPixel struct {
+	R, G, B uint8
+	Opaque  bool
+	Slot    int
+}
+
+func setupAsciiConvertFlags(flags *flag.FlagSet) {
+	flags.IntVar(&convertWidth, "width", convertWidth, "Width of the art in terminal cells")
+	flags.StringVar(&convertMode, "mode", convertMode, "Characters used to draw the art ("+strings.Join(ConvertModes, ", ")+")")
+	flags.StringVar(&convertOutput, "o", "", "Write the art to the given file instead of stdout")
+	flags.BoolVar(&convertKeepBackground, "keep-background", false, "Keep the background of images without transparency instead of removing the color of their corners")
+}
+
+func runAsciiConvert(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Error: expected a single image")
+		return 2
+	}
+	if !slices.Contains(ConvertModes, convertMode) {
+		fmt.Fprintf(os.Stderr, "Error: invalid mode '%s', expected one of: %s\n", convertMode, strings.Join(ConvertModes, ", "))
+		return 2
+	}
+	file, err := os.Open(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	defer file.Close()
+	img, _, err := image.Decode(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not decode %s: %s\n", args[0], err)
+		return 1
+	}
+
+	art := ConvertImage(img, max(convertWidth, 1), convertMode, convertKeepBackground)
+	if convertOutput == "" {
+		fmt.Print(art)
+		return 0
+	}
+	if err := os.WriteFile(convertOutput, []byte(art), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// samplePixels scales an image down to the given size by averaging the pixels covered by each sample
+func samplePixels(img image.Image, width, height int) []convertPixel {
+	bounds := img.Bounds()
+	pixels := make([]convertPixel, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			x0, x1 := bounds.Min.X+x*bounds.Dx()/width, bounds.Min.X+(x+1)*bounds.Dx()/width
+			y0, y1 := bounds.Min.Y+y*bounds.Dy()/height, bounds.Min.Y+(y+1)*bounds.Dy()/height
+			x1, y1 = max(x1, x0+1), max(y1, y0+1)
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					count++
+				}
+			}
+			if a == 0 {
+				continue
+			}
+			// The colors are premultiplied by alpha
+			pixels[y*width+x] = convertPixel{
+				R:      uint8(r * 0xff / a),
+				G:      uint8(g * 0xff / a),
+				B:      uint8(b * 0xff / a),
+				Opaque: a/count >= 0x8000,
+			}
+		}
+	}
+	return pixels
+}
+
+// removeBackground makes the pixels close to the color of the top left corner transparent if the image has no transparency
+func removeBackground(pixels []convertPixel) {
+	for _, pixel := range pixels {
+		if !pixel.Opaque {
+			return
+		}
+	}
+	background := pixels[0]
+	for i, pixel := range pixels {
+		if colorDistance(pixel.R, pixel.G, pixel.B, background.R, background.G, background.B) < 48*48 {
+			pixels[i].Opaque = false
+		}
+	}
+}
+
+// quantizePixels groups the colors of the opaque pixels into at most the given amount of slots using k-means clustering.
+// Slots are sorted by the amount of pixels using them and returned as hex colors
+func quantizePixels(pixels []convertPixel, slots int) []string {
+	// Start from the most common colors, grouping similar colors together
+	buckets := make(map[[3]uint8]int)
+	for _, pixel := range pixels {
+		if pixel.Opaque {
+			buckets[[3]uint8{pixel.R & 0xe0, pixel.G & 0xe0, pixel.B & 0xe0}]++
+		}
+	}
+	keys := make([][3]uint8, 0, len(buckets))
+	for key := range buckets {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if buckets[keys[i]] != buckets[keys[j]] {
+			return buckets[keys[i]] > buckets[keys[j]]
+		}
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	centers := make([][3]float64, 0, slots)
+	for _, key := range keys[:min(len(keys), slots)] {
+		centers = append(centers, [3]float64{float64(key[0]) + 16, float64(key[1]) + 16, float64(key[2]) + 16})
+	}
+	if len(centers) == 0 {
+		return nil
+	}
+
+	counts := make([]int, len(centers))
+	for iteration := 0; iteration < 10; iteration++ {
+		sums := make([][3]float64, len(centers))
+		counts = make([]int, len(centers))
+		for i, pixel := range pixels {
+			if !pixel.Opaque {
+				continue
+			}
+			best, bestDistance := 0, -1.0
+			for c, center := range centers {
+				dr, dg, db := float64(pixel.R)-center[0], float64(pixel.G)-center[1], float64(pixel.B)-center[2]
+				if distance := dr*dr + dg*dg + db*db; bestDistance < 0 || distance < bestDistance {
+					best, bestDistance = c, distance
+				}
+			}
+			pixels[i].Slot = best
+			sums[best] = [3]float64{sums[best][0] + float64(pixel.R), sums[best][1] + float64(pixel.G), sums[best][2] + float64(pixel.B)}
+			counts[best]++
+		}
+		for c := range centers {
+			if counts[c] != 0 {
+				centers[c] = [3]float64{sums[c][0] / float64(counts[c]), sums[c][1] / float64(counts[c]), sums[c][2] / float64(counts[c])}
+			}
+		}
+	}
+
+	// Order slots by usage and drop unused ones
+	order := make([]int, len(centers))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return counts[order[i]] > counts[order[j]]
+	})
+	slotOf := make([]int, len(centers))
+	var colors []string
+	for rank, c := range order {
+		slotOf[c] = rank + 1
+		if counts[c] != 0 {
+			colors = append(colors, fmt.Sprintf("#%02x%02x%02x", uint8(centers[c][0]+0.5), uint8(centers[c][1]+0.5), uint8(centers[c][2]+0.5)))
+		}
+	}
+	for i := range pixels {
+		if pixels[i].Opaque {
+			pixels[i].Slot = slotOf[pixels[i].Slot]
+		}
+	}
+	return colors
+}
+
+// ConvertImage converts an image into an ascii art file the given amount of cells wide, including a '#/' color header
+func ConvertImage(img image.Image, width int, mode string, keepBackground bool) string {
+	// Terminal cells are about twice as high as wide
+	bounds := img.Bounds()
+	height := max((bounds.Dy()*width+bounds.Dx())/(bounds.Dx()*2), 1)
+	cellWidth, cellHeight := 1, 1
+	switch mode {
+	case "blocks":
+		cellHeight = 2
+	case "braille":
+		cellWidth, cellHeight = 2, 4
+	}
+	pixelsWidth := width * cellWidth
+	pixels := samplePixels(img, pixelsWidth, height*cellHeight)
+	if !keepBackground {
+		removeBackground(pixels)
+	}
+	colors := quantizePixels(pixels, 6)
+
+	art := "#/" + strings.Join(colors, ";") + "\n"
+	currentSlot := 0
+	for y := 0; y < height; y++ {
+		line := ""
+		for x := 0; x < width; x++ {
+			char, slot := " ", 0
+			switch mode {
+			case "ascii":
+				pixel := pixels[y*pixelsWidth+x]
+				if pixel.Opaque {
+					luminance := (2126*int(pixel.R) + 7152*int(pixel.G) + 722*int(pixel.B)) / 10000
+					char, slot = string(asciiRamp[luminance*len(asciiRamp)/256]), pixel.Slot
+				}
+			case "blocks":
+				top, bottom := pixels[2*y*pixelsWidth+x], pixels[(2*y+1)*pixelsWidth+x]
+				switch {
+				case top.Opaque && bottom.Opaque:
+					char, slot = "█", top.Slot
+				case top.Opaque:
+					char, slot = "▀", top.Slot
+				case bottom.Opaque:
+					char, slot = "▄", bottom.Slot
+				}
+			case "braille":
+				bits := 0
+				slotCounts := make(map[int]int)
+				for dy := 0; dy < 4; dy++ {
+					for dx := 0; dx < 2; dx++ {
+						if pixel := pixels[(4*y+dy)*pixelsWidth+2*x+dx]; pixel.Opaque {
+							bits |= brailleDots[dy][dx]
+							slotCounts[pixel.Slot]++
+						}
+					}
+				}
+				if bits != 0 {
+					char = string(rune(0x2800 + bits))
+					// Use the most common color of the dots
+					for s, count := range slotCounts {
+						if slot == 0 || count > slotCounts[slot] || (count == slotCounts[slot] && s < slot) {
+							slot = s
+						}
+					}
+				}
+			}
+			if slot != 0 && slot != currentSlot {
+				line += "${C" + strconv.Itoa(slot) + "}"
+				currentSlot = slot
+			}
+			line += char
+		}
+		art += strings.TrimRight(line, " ") + "\n"
+	}
+	return strings.TrimRight(art, "\n") + "${C0}\n"
+}
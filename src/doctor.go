@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+type DoctorStatus string
+
+const (
+	DoctorOK   DoctorStatus = "[ OK ]"
+	DoctorWarn DoctorStatus = "[WARN]"
+	DoctorFail DoctorStatus = "[FAIL]"
+)
+
+func runDoctor(configErr error) int {
+	exitCode := 0
+	report := func(status DoctorStatus, message, hint string) {
+		fmt.Printf("%s %s\n", status, message)
+		if hint != "" {
+			fmt.Printf("       %s\n", hint)
+		}
+		if status == DoctorFail {
+			exitCode = 1
+		}
+	}
+	userConfigDir, _ := os.UserConfigDir()
+
+	// Check configuration files
+	if configErr != nil {
+		report(DoctorFail, fmt.Sprintf("Could not load config: %s", configErr),
+			fmt.Sprintf("Copy config.yaml and fetch_script.sh from %s into %s", path.Join(systemConfigDir, "stormfetch"), path.Join(userConfigDir, "stormfetch")))
+	} else {
+		report(DoctorOK, fmt.Sprintf("Config file: %s", configPath), "")
+	}
+	if _, err := os.Stat("/bin/bash"); err != nil {
+		report(DoctorFail, "/bin/bash not found: the fetch script cannot be run", "Install bash using your package manager")
+	} else if fetchScriptPath != "" {
+		if output, err := exec.Command("/bin/bash", "-n", fetchScriptPath).CombinedOutput(); err != nil {
+			report(DoctorFail, fmt.Sprintf("Fetch script %s has syntax errors", fetchScriptPath), strings.TrimSpace(string(output)))
+		} else {
+			report(DoctorOK, fmt.Sprintf("Fetch script: %s", fetchScriptPath), "")
+		}
+	}
+
+	// Check ascii art
+	id := defaultAsciiID()
+	if _, ok := EmbeddedAsciiArts[id]; ok && GetAsciiArtPath(id) == "" {
+		report(DoctorOK, fmt.Sprintf("Ascii art: embedded %s", id), "")
+	} else if asciiPath := GetAsciiArtPath(id); asciiPath == "" {
+		report(DoctorWarn, fmt.Sprintf("No ascii art found for '%s': the default art will be shown", id),
+			fmt.Sprintf("Add an art file named '%s' to %s, map it to an existing art in ascii_aliases or set distro_ascii in the config", id, path.Join(userConfigDir, "stormfetch/ascii")))
+	} else if bytes, err := os.ReadFile(asciiPath); err != nil {
+		report(DoctorFail, fmt.Sprintf("Could not read ascii art %s: %s", asciiPath, err), "")
+	} else if colors, _, err := ParseAsciiHeader(string(bytes)); err != nil {
+		report(DoctorFail, fmt.Sprintf("Ascii art %s has an invalid color header: %s", asciiPath, err), "The first line must look like '#/4;27;4;11'")
+	} else if colors == nil {
+		report(DoctorWarn, fmt.Sprintf("Ascii art %s has no '#/' color header: ansii_colors from the config will be used", asciiPath), "")
+	} else {
+		report(DoctorOK, fmt.Sprintf("Ascii art: %s", asciiPath), "")
+	}
+
+	// Check theme
+	if config.Theme != "" && config.Theme != "none" {
+		if _, err := GetTheme(); err != nil {
+			report(DoctorFail, fmt.Sprintf("Could not load theme: %s", err), fmt.Sprintf("Available themes: %s", strings.Join(ListThemes(), ", ")))
+		} else {
+			report(DoctorOK, fmt.Sprintf("Theme: %s", GetThemePath(config.Theme)), "")
+		}
+	}
+
+	// Check external programs
+	if _, err := exec.LookPath("lspci"); err != nil {
+		report(DoctorWarn, "lspci not found: GPU information will be empty", "Install pciutils using your package manager")
+	} else {
+		report(DoctorOK, "lspci found", "")
+	}
+	if _, err := exec.LookPath("ldd"); err != nil {
+		report(DoctorWarn, "ldd not found: libc will be reported as Unknown", "")
+	} else {
+		report(DoctorOK, "ldd found", "")
+	}
+	var packageManagers []string
+	for _, pm := range PackageManagers {
+		if _, err := exec.LookPath(pm.ExecutableName); err == nil {
+			packageManagers = append(packageManagers, pm.Name)
+		}
+	}
+	if len(packageManagers) == 0 {
+		report(DoctorWarn, "No supported package manager found: package count will be empty", "")
+	} else {
+		report(DoctorOK, fmt.Sprintf("Package managers: %s", strings.Join(packageManagers, ", ")), "")
+	}
+
+	// Check system interfaces
+	if _, err := os.ReadDir("/dev/disk/by-label"); os.IsNotExist(err) {
+		report(DoctorWarn, "/dev/disk/by-label does not exist: partitions will be shown by mountpoint", "")
+	} else if err != nil {
+		report(DoctorWarn, fmt.Sprintf("Could not read /dev/disk/by-label: %s", err), "Partition information will be empty")
+	} else {
+		report(DoctorOK, "/dev/disk/by-label is readable", "")
+	}
+	if err := CheckDisplay(); err != nil {
+		report(DoctorWarn, fmt.Sprintf("No usable display for GLFW: %s", err), "Monitor information will be empty")
+	} else {
+		report(DoctorOK, "Display is usable through GLFW", "")
+	}
+
+	// Check which collectors return empty values
+	config.EnableHistory = false
+	env := SetupFetchEnv(nil, nil)
+	var empty []string
+	for _, key := range []string{"PACKAGES", "DISTRO_LONG_NAME", "CPU_MODEL", "MOTHERBOARD", "MEM_TOTAL", "MOUNTED_PARTITIONS", "CONNECTED_GPUS", "CONNECTED_MONITORS", "DE_WM", "USER_SHELL", "DISPLAY_PROTOCOL", "LIBC", "INIT_SYSTEM", "LOCAL_IPV4"} {
+		if value := env[key]; value == "" || value == "Unknown" {
+			empty = append(empty, key)
+		}
+	}
+	if len(empty) != 0 {
+		report(DoctorWarn, fmt.Sprintf("The following variables will be empty: %s", strings.Join(empty, ", ")), "")
+	} else {
+		report(DoctorOK, "All variables are set", "")
+	}
+
+	return exitCode
+}
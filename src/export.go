@@ -0,0 +1,440 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var OutputFormats = []string{"terminal", "svg", "png", "html"}
+
+var OutputFormat = "terminal"
+
+var OutputFile = ""
+
+var TextFormats = []string{"terminal", "markdown", "text"}
+
+var TextFormat = "terminal"
+
+// maxAlignedLabelWidth is the width of the longest label whose value is aligned with the others by --format text
+const maxAlignedLabelWidth = 24
+
+// StyledRun is a piece of a rendered line sharing the same graphic rendition, starting at the given cell
+type StyledRun struct {
+	Text   string
+	Column int
+	Width  int
+	State  SGRState
+}
+
+// InfoField is a line of the fetch script output split into its label and value
+type InfoField struct {
+	Label string
+	Value string
+}
+
+// ExportPalette holds the colors used to draw the output into a file
+type ExportPalette struct {
+	Background [3]uint8
+	Foreground [3]uint8
+	Colors     [16][3]uint8
+}
+
+// ParseStyledLines splits a rendered frame into lines of styled runs, dropping the escape sequences other than SGR
+func ParseStyledLines(frame string) [][]StyledRun {
+	var lines [][]StyledRun
+	state := SGRState{}
+	for _, line := range strings.Split(frame, "\n") {
+		var runs []StyledRun
+		column := 0
+		current := StyledRun{State: state}
+		flush := func() {
+			if current.Text != "" {
+				runs = append(runs, current)
+			}
+			current = StyledRun{Column: column, State: state}
+		}
+		line = strings.TrimRight(line, "\r")
+		for line != "" {
+			if escape := leadingAnsiRegex.FindString(line); escape != "" {
+				line = line[len(escape):]
+				if match := sgrRegex.FindStringSubmatch(escape); match != nil {
+					state.Apply(match[1])
+					flush()
+				}
+				continue
+			}
+			grapheme, width := NextGrapheme(line)
+			line = line[len(grapheme):]
+			if grapheme == "\t" {
+				// Expand tabs to the next tab stop
+				grapheme = strings.Repeat(" ", 8-column%8)
+				width = len(grapheme)
+			}
+			current.Text += grapheme
+			current.Width += width
+			column += width
+		}
+		flush()
+		lines = append(lines, runs)
+	}
+	// Drop trailing empty lines
+	for len(lines) > 1 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// styledLinesWidth returns the amount of cells taken by the widest line
+func styledLinesWidth(lines [][]StyledRun) int {
+	width := 0
+	for _, runs := range lines {
+		if len(runs) != 0 {
+			last := runs[len(runs)-1]
+			width = max(width, last.Column+last.Width)
+		}
+	}
+	return width
+}
+
+// GetExportPalette returns the colors of the export_* config keys, using the xterm palette for the basic colors not set
+func GetExportPalette() (ExportPalette, error) {
+	palette := ExportPalette{Colors: basicPalette}
+	parse := func(name, value string) ([3]uint8, error) {
+		color, err := ParseColor(value)
+		if err != nil || color.Mode == ColorDefault {
+			return [3]uint8{}, fmt.Errorf("invalid %s color '%s'", name, value)
+		}
+		r, g, b := color.RGB()
+		return [3]uint8{r, g, b}, nil
+	}
+	var err error
+	if palette.Background, err = parse("export_background", config.ExportBackground); err != nil {
+		return palette, err
+	}
+	if palette.Foreground, err = parse("export_foreground", config.ExportForeground); err != nil {
+		return palette, err
+	}
+	if len(config.ExportPalette) > 16 {
+		return palette, fmt.Errorf("export_palette holds %d colors, at most 16 are supported", len(config.ExportPalette))
+	}
+	for i, value := range config.ExportPalette {
+		if palette.Colors[i], err = parse("export_palette", value); err != nil {
+			return palette, err
+		}
+	}
+	return palette, nil
+}
+
+// resolve returns the RGB value of a terminal color, using the palette for the basic colors
+func (palette ExportPalette) resolve(color TermColor, fallback [3]uint8) [3]uint8 {
+	switch {
+	case color.Mode == ColorDefault:
+		return fallback
+	case color.Mode == Color16 || (color.Mode == Color256 && color.Index < 16):
+		return palette.Colors[color.Index%16]
+	}
+	r, g, b := color.RGB()
+	return [3]uint8{r, g, b}
+}
+
+// RunColors returns the foreground and background colors of a run, along with whether the background differs from the default one
+func (palette ExportPalette) RunColors(state SGRState) ([3]uint8, [3]uint8, bool) {
+	foreground := palette.resolve(state.Foreground, palette.Foreground)
+	background := palette.resolve(state.Background, palette.Background)
+	hasBackground := state.Background.Mode != ColorDefault
+	if state.Reverse {
+		foreground, background = background, foreground
+		hasBackground = true
+	}
+	if state.Dim {
+		for i := range foreground {
+			foreground[i] = uint8((int(foreground[i]) + int(background[i])) / 2)
+		}
+	}
+	if state.Hidden {
+		foreground = background
+	}
+	return foreground, background, hasBackground
+}
+
+func hexColor(color [3]uint8) string {
+	return fmt.Sprintf("#%02x%02x%02x", color[0], color[1], color[2])
+}
+
+// RenderExport renders the output of stormfetch with colors and converts it into the given format
+func RenderExport(format string) ([]byte, error) {
+	// Render every color as is and independently of the current terminal
+	if config.Color != "never" {
+		config.Color = "always"
+	}
+	config.ColorDepth = "truecolor"
+	config.LogoImage = ""
+	config.ResponsiveLayout = false
+	theme, err := GetTheme()
+	if err != nil {
+		return nil, err
+	}
+	if theme != nil {
+		if theme.Background != "" {
+			config.ExportBackground = theme.Background
+		}
+		if theme.Foreground != "" {
+			config.ExportForeground = theme.Foreground
+		}
+		if theme.Padding != nil {
+			config.ExportPadding = *theme.Padding
+		}
+	}
+	palette, err := GetExportPalette()
+	if err != nil {
+		return nil, err
+	}
+	final, err := RenderStormfetch()
+	if err != nil {
+		return nil, err
+	}
+	lines := ParseStyledLines(final)
+	switch format {
+	case "svg":
+		return []byte(EncodeSVG(lines, palette)), nil
+	case "png":
+		return EncodePNG(lines, palette)
+	case "html":
+		return []byte(EncodeHTML(lines, palette, config.ExportHTMLFragment)), nil
+	}
+	return nil, fmt.Errorf("output format '%s' cannot be exported", format)
+}
+
+// runExport writes the output in the format given to --output into the file given to --output-file, or stdout
+func runExport() int {
+	if !slices.Contains(OutputFormats, OutputFormat) {
+		fmt.Fprintf(os.Stderr, "Error: invalid output format '%s', expected one of: %s\n", OutputFormat, strings.Join(OutputFormats, ", "))
+		return 2
+	}
+	if TextFormat != "terminal" {
+		fmt.Fprintln(os.Stderr, "Error: --format cannot be combined with --output")
+		return 2
+	}
+	if OutputFormat == "png" && OutputFile == "" && IsTerminal(os.Stdout) {
+		fmt.Fprintln(os.Stderr, "Error: expected a file to write the PNG image to with --output-file")
+		return 2
+	}
+	data, err := RenderExport(OutputFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	if OutputFile == "" {
+		os.Stdout.Write(data)
+		return 0
+	}
+	if err := os.WriteFile(OutputFile, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// EncodeSVG draws styled lines as monospace text on a rectangle of the background color
+func EncodeSVG(lines [][]StyledRun, palette ExportPalette) string {
+	fontSize := float64(max(config.ExportFontSize, 1))
+	cellWidth, lineHeight := fontSize*0.6, fontSize*1.2
+	padding := float64(max(config.ExportPadding, 0))
+	width := float64(styledLinesWidth(lines))*cellWidth + 2*padding
+	height := float64(len(lines))*lineHeight + 2*padding
+	format := func(value float64) string {
+		return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%s\" height=\"%s\" viewBox=\"0 0 %s %s\">\n",
+		format(width), format(height), format(width), format(height)))
+	builder.WriteString(fmt.Sprintf("<rect width=\"100%%\" height=\"100%%\" rx=\"%s\" fill=\"%s\"/>\n", format(fontSize/2), hexColor(palette.Background)))
+	builder.WriteString(fmt.Sprintf("<g font-family=\"%s\" font-size=\"%s\" xml:space=\"preserve\">\n", html.EscapeString(config.ExportFont), format(fontSize)))
+	for i, runs := range lines {
+		top := padding + float64(i)*lineHeight
+		for _, run := range runs {
+			foreground, background, hasBackground := palette.RunColors(run.State)
+			x := padding + float64(run.Column)*cellWidth
+			if hasBackground {
+				builder.WriteString(fmt.Sprintf("<rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" fill=\"%s\"/>\n",
+					format(x), format(top), format(float64(run.Width)*cellWidth), format(lineHeight), hexColor(background)))
+			}
+			// Only draw the text between the leading and trailing spaces
+			text := strings.TrimLeft(run.Text, " ")
+			x += float64(len(run.Text)-len(text)) * cellWidth
+			text = strings.TrimRight(text, " ")
+			textWidth := StringWidth(text)
+			if text == "" || run.State.Hidden {
+				continue
+			}
+			// Stretch the text over its cells so that lines stay aligned whatever the font
+			attributes := fmt.Sprintf("x=\"%s\" y=\"%s\" fill=\"%s\" textLength=\"%s\" lengthAdjust=\"spacingAndGlyphs\"",
+				format(x), format(top+lineHeight*0.8), hexColor(foreground), format(float64(textWidth)*cellWidth))
+			if run.State.Bold {
+				attributes += " font-weight=\"bold\""
+			}
+			if run.State.Italic {
+				attributes += " font-style=\"italic\""
+			}
+			var decorations []string
+			if run.State.Underline {
+				decorations = append(decorations, "underline")
+			}
+			if run.State.Strikethrough {
+				decorations = append(decorations, "line-through")
+			}
+			if len(decorations) != 0 {
+				attributes += " text-decoration=\"" + strings.Join(decorations, " ") + "\""
+			}
+			builder.WriteString("<text " + attributes + ">" + html.EscapeString(text) + "</text>\n")
+		}
+	}
+	builder.WriteString("</g>\n</svg>\n")
+	return builder.String()
+}
+
+// EncodeHTML converts styled lines into spans of a preformatted block, either alone or within a complete page.
+// Styles are set inline so that the block can be embedded as is into another page
+func EncodeHTML(lines [][]StyledRun, palette ExportPalette, fragment bool) string {
+	builder := strings.Builder{}
+	if !fragment {
+		builder.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>stormfetch</title>\n</head>\n")
+		builder.WriteString(fmt.Sprintf("<body style=\"margin: 0; background-color: %s;\">\n", hexColor(palette.Background)))
+	}
+	builder.WriteString(fmt.Sprintf("<pre class=\"stormfetch\" style=\"display: inline-block; margin: 0; padding: %dpx; color: %s; background-color: %s; font-family: %s; font-size: %dpx; line-height: 1.2;\">",
+		max(config.ExportPadding, 0), hexColor(palette.Foreground), hexColor(palette.Background), html.EscapeString(config.ExportFont), max(config.ExportFontSize, 1)))
+	for i, runs := range lines {
+		if i != 0 {
+			builder.WriteString("\n")
+		}
+		for _, run := range runs {
+			foreground, background, hasBackground := palette.RunColors(run.State)
+			var styles []string
+			if foreground != palette.Foreground {
+				styles = append(styles, "color: "+hexColor(foreground))
+			}
+			if hasBackground {
+				styles = append(styles, "background-color: "+hexColor(background))
+			}
+			if run.State.Bold {
+				styles = append(styles, "font-weight: bold")
+			}
+			if run.State.Italic {
+				styles = append(styles, "font-style: italic")
+			}
+			var decorations []string
+			if run.State.Underline {
+				decorations = append(decorations, "underline")
+			}
+			if run.State.Strikethrough {
+				decorations = append(decorations, "line-through")
+			}
+			if len(decorations) != 0 {
+				styles = append(styles, "text-decoration: "+strings.Join(decorations, " "))
+			}
+			if len(styles) == 0 {
+				builder.WriteString(html.EscapeString(run.Text))
+				continue
+			}
+			builder.WriteString("<span style=\"" + strings.Join(styles, "; ") + "\">" + html.EscapeString(run.Text) + "</span>")
+		}
+	}
+	builder.WriteString("</pre>\n")
+	if !fragment {
+		builder.WriteString("</body>\n</html>\n")
+	}
+	return builder.String()
+}
+
+// ParseInfoFields splits each line of the fetch script output at its first ': ', leaving the label of the other lines empty
+func ParseInfoFields(info string) []InfoField {
+	var fields []InfoField
+	for _, line := range strings.Split(strings.TrimRight(StripAnsii(info), "\n"), "\n") {
+		line = strings.TrimRight(line, " \t")
+		if label, value, ok := strings.Cut(line, ": "); ok && strings.TrimSpace(label) != "" {
+			fields = append(fields, InfoField{Label: label, Value: strings.TrimSpace(value)})
+		} else {
+			fields = append(fields, InfoField{Value: line})
+		}
+	}
+	return fields
+}
+
+// lastAsciiFrame returns the last frame of the art without escape sequences, or an empty string if the art is hidden
+func lastAsciiFrame(output *RenderedOutput) string {
+	if output.Layout.Position == "none" {
+		return ""
+	}
+	frames := SplitAsciiFrames(StripAnsii(output.Ascii))
+	return strings.TrimRight(frames[len(frames)-1], "\n")
+}
+
+// FormatText returns the output without escape sequences, aligning the values of the information
+func FormatText(output *RenderedOutput) string {
+	fields := ParseInfoFields(output.Info)
+	// Values following very long labels, such as the mountpoints of partitions, are left unaligned
+	labelWidth := 0
+	for _, field := range fields {
+		if width := StringWidth(field.Label); width <= maxAlignedLabelWidth {
+			labelWidth = max(labelWidth, width)
+		}
+	}
+	var lines []string
+	for _, field := range fields {
+		if field.Label == "" {
+			lines = append(lines, field.Value)
+		} else {
+			lines = append(lines, PadRight(field.Label+":", labelWidth+1)+" "+field.Value)
+		}
+	}
+	frame, _ := LayoutFrame(lastAsciiFrame(output), strings.Join(lines, "\n"), output.Layout)
+	return strings.TrimRight(StripAnsii(frame), "\n") + "\n"
+}
+
+// FormatMarkdown returns the art in a code block followed by a table of the information
+func FormatMarkdown(output *RenderedOutput) string {
+	builder := strings.Builder{}
+	if ascii := lastAsciiFrame(output); strings.TrimSpace(ascii) != "" {
+		builder.WriteString("```\n" + ascii + "\n```\n\n")
+	}
+	escape := func(str string) string {
+		return strings.ReplaceAll(str, "|", "\\|")
+	}
+	builder.WriteString("| Property | Value |\n| --- | --- |\n")
+	for _, field := range ParseInfoFields(output.Info) {
+		if strings.TrimSpace(field.Value) == "" && field.Label == "" {
+			continue
+		}
+		builder.WriteString("| " + escape(field.Label) + " | " + escape(strings.TrimSpace(field.Value)) + " |\n")
+	}
+	return builder.String()
+}
+
+// runTextFormat prints the output in the format given to --format, without escape sequences
+func runTextFormat() int {
+	if !slices.Contains(TextFormats, TextFormat) {
+		fmt.Fprintf(os.Stderr, "Error: invalid format '%s', expected one of: %s\n", TextFormat, strings.Join(TextFormats, ", "))
+		return 2
+	}
+	// Pasted text does not depend on the current terminal
+	config.Color = "never"
+	config.LogoImage = ""
+	config.ResponsiveLayout = false
+	output, err := RenderStormfetchOutput()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	if TextFormat == "markdown" {
+		fmt.Print(FormatMarkdown(output))
+	} else {
+		fmt.Print(FormatText(output))
+	}
+	return 0
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testPalette = ExportPalette{
+	Background: [3]uint8{0, 0, 0},
+	Foreground: [3]uint8{200, 200, 200},
+	Colors:     basicPalette,
+}
+
+func TestParseStyledLines(t *testing.T) {
+	red := SGRState{Foreground: TermColor{Mode: Color16, Index: 1}}
+	boldRed := SGRState{Bold: true, Foreground: TermColor{Mode: Color16, Index: 1}}
+	tests := []struct {
+		name  string
+		frame string
+		want  [][]StyledRun
+	}{
+		{"plain text", "ab\ncd", [][]StyledRun{
+			{{Text: "ab", Width: 2}},
+			{{Text: "cd", Width: 2}},
+		}},
+		{"styled runs", "a\033[31mbc\033[1md\033[0me", [][]StyledRun{
+			{{Text: "a", Width: 1}, {Text: "bc", Column: 1, Width: 2, State: red}, {Text: "d", Column: 3, Width: 1, State: boldRed}, {Text: "e", Column: 4, Width: 1}},
+		}},
+		{"state is kept across lines", "\033[31mab\ncd\033[0m", [][]StyledRun{
+			{{Text: "ab", Width: 2, State: red}},
+			{{Text: "cd", Width: 2, State: red}},
+		}},
+		{"wide characters take two cells", "日本\033[31mx", [][]StyledRun{
+			{{Text: "日本", Width: 4}, {Text: "x", Column: 4, Width: 1, State: red}},
+		}},
+		{"combining characters take no cell", "e\u0301\033[31mx", [][]StyledRun{
+			{{Text: "e\u0301", Width: 1}, {Text: "x", Column: 1, Width: 1, State: red}},
+		}},
+		{"tabs are expanded", "ab\tc\t\td", [][]StyledRun{
+			{{Text: "ab      c               d", Width: 25}},
+		}},
+		{"other escape sequences are dropped", "\033[2Ka\033[?25lb\033]0;title\007c", [][]StyledRun{
+			{{Text: "abc", Width: 3}},
+		}},
+		{"carriage returns are dropped", "ab\r\ncd\r", [][]StyledRun{
+			{{Text: "ab", Width: 2}},
+			{{Text: "cd", Width: 2}},
+		}},
+		{"empty lines are kept except at the end", "a\n\nb\n\n\n", [][]StyledRun{
+			{{Text: "a", Width: 1}},
+			nil,
+			{{Text: "b", Width: 1}},
+		}},
+		{"empty frame", "", [][]StyledRun{nil}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ParseStyledLines(test.frame); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("ParseStyledLines(%q) = %+v, want %+v", test.frame, got, test.want)
+			}
+		})
+	}
+}
+
+func TestStyledLinesWidth(t *testing.T) {
+	lines := ParseStyledLines("ab\n\033[31m日本語\033[0m x\n")
+	if got := styledLinesWidth(lines); got != 8 {
+		t.Errorf("styledLinesWidth() = %d, want 8", got)
+	}
+}
+
+func TestRunColors(t *testing.T) {
+	red := testPalette.Colors[1]
+	tests := []struct {
+		name          string
+		state         SGRState
+		foreground    [3]uint8
+		background    [3]uint8
+		hasBackground bool
+	}{
+		{"default", SGRState{}, testPalette.Foreground, testPalette.Background, false},
+		{"basic color uses the palette", SGRState{Foreground: TermColor{Mode: Color16, Index: 1}}, red, testPalette.Background, false},
+		{"first 256 colors use the palette", SGRState{Foreground: TermColor{Mode: Color256, Index: 1}}, red, testPalette.Background, false},
+		{"256 colors", SGRState{Foreground: TermColor{Mode: Color256, Index: 196}}, [3]uint8{255, 0, 0}, testPalette.Background, false},
+		{"truecolor", SGRState{Foreground: TermColor{Mode: ColorRGB, R: 1, G: 2, B: 3}}, [3]uint8{1, 2, 3}, testPalette.Background, false},
+		{"background", SGRState{Background: TermColor{Mode: Color16, Index: 1}}, testPalette.Foreground, red, true},
+		{"reverse", SGRState{Reverse: true, Foreground: TermColor{Mode: Color16, Index: 1}}, testPalette.Background, red, true},
+		{"dim", SGRState{Dim: true}, [3]uint8{100, 100, 100}, testPalette.Background, false},
+		{"hidden", SGRState{Hidden: true, Background: TermColor{Mode: ColorRGB, R: 9, G: 9, B: 9}}, [3]uint8{9, 9, 9}, [3]uint8{9, 9, 9}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			foreground, background, hasBackground := testPalette.RunColors(test.state)
+			if foreground != test.foreground || background != test.background || hasBackground != test.hasBackground {
+				t.Errorf("RunColors(%+v) = %v, %v, %t, want %v, %v, %t", test.state,
+					foreground, background, hasBackground, test.foreground, test.background, test.hasBackground)
+			}
+		})
+	}
+}
+
+func TestEncodeSVG(t *testing.T) {
+	lines := ParseStyledLines("  \033[1;31m<a & b>\033[0m  \n\033[8msecret\033[0m\n\033[7m   \033[0m")
+	svg := EncodeSVG(lines, testPalette)
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	var texts []string
+	inText := false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err != io.EOF {
+				t.Fatalf("EncodeSVG() is not valid XML: %s\n%s", err, svg)
+			}
+			break
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			inText = token.Name.Local == "text"
+		case xml.EndElement:
+			inText = false
+		case xml.CharData:
+			if inText {
+				texts = append(texts, string(token))
+			}
+		}
+	}
+	// Spaces around the text are skipped, hidden text is not drawn
+	if want := []string{"<a & b>"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("EncodeSVG() draws %q, want %q", texts, want)
+	}
+	if !strings.Contains(svg, `font-weight="bold"`) {
+		t.Errorf("EncodeSVG() does not draw bold text:\n%s", svg)
+	}
+	// The reversed spaces are drawn as a rectangle of the foreground color
+	if !strings.Contains(svg, `fill="#c8c8c8"/>`) {
+		t.Errorf("EncodeSVG() does not draw the reversed background:\n%s", svg)
+	}
+}
+
+func TestEncodeHTML(t *testing.T) {
+	lines := ParseStyledLines("a<b\n\033[4;32m&\033[0m")
+	got := EncodeHTML(lines, testPalette, true)
+	if !strings.HasPrefix(got, "<pre ") || !strings.HasSuffix(got, "</pre>\n") {
+		t.Errorf("EncodeHTML() fragment is not a single block:\n%s", got)
+	}
+	want := ">a&lt;b\n<span style=\"color: #00cd00; text-decoration: underline\">&amp;</span></pre>"
+	if !strings.Contains(got, want) {
+		t.Errorf("EncodeHTML() = %q, want it to contain %q", got, want)
+	}
+	if page := EncodeHTML(lines, testPalette, false); !strings.HasPrefix(page, "<!DOCTYPE html>") || !strings.HasSuffix(page, "</html>\n") {
+		t.Errorf("EncodeHTML() page is not a complete document:\n%s", page)
+	}
+}
+
+func TestParseInfoFields(t *testing.T) {
+	tests := []struct {
+		name string
+		info string
+		want []InfoField
+	}{
+		{"labels and values", "Distribution: Arch Linux\nKernel:   6.1.0\n", []InfoField{
+			{Label: "Distribution", Value: "Arch Linux"},
+			{Label: "Kernel", Value: "6.1.0"},
+		}},
+		{"escape sequences are removed", "\033[1;36mShell:\033[0m \033[37mbash\033[0m", []InfoField{
+			{Label: "Shell", Value: "bash"},
+		}},
+		{"lines without a label", "user@host\n---------\n\nCPU: x", []InfoField{
+			{Value: "user@host"},
+			{Value: "---------"},
+			{Value: ""},
+			{Label: "CPU", Value: "x"},
+		}},
+		{"only the first separator splits", "Partition /mnt/a: b (ext4): 1 GiB/2 GiB", []InfoField{
+			{Label: "Partition /mnt/a", Value: "b (ext4): 1 GiB/2 GiB"},
+		}},
+		{"colons without a space do not split", "Time: 12:30\nhttp://example.com", []InfoField{
+			{Label: "Time", Value: "12:30"},
+			{Value: "http://example.com"},
+		}},
+		{"blank labels are not labels", "   : value  ", []InfoField{
+			{Value: "   : value"},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ParseInfoFields(test.info); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("ParseInfoFields(%q) = %+v, want %+v", test.info, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatMarkdown(t *testing.T) {
+	output := &RenderedOutput{
+		Layout: Layout{Position: "left"},
+		Ascii:  "\033[34m /\\\n/__\\\033[0m",
+		Info:   "\033[1mhost\033[0m\nShell: a | b\n\nCPU: x\n",
+	}
+	want := "```\n /\\\n/__\\\n```\n\n" +
+		"| Property | Value |\n| --- | --- |\n" +
+		"|  | host |\n" +
+		"| Shell | a \\| b |\n" +
+		"| CPU | x |\n"
+	if got := FormatMarkdown(output); got != want {
+		t.Errorf("FormatMarkdown() = %q, want %q", got, want)
+	}
+	// Hidden arts are left out
+	output.Layout.Position = "none"
+	if got := FormatMarkdown(output); strings.Contains(got, "```") {
+		t.Errorf("FormatMarkdown() with a hidden art = %q", got)
+	}
+}
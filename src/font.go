@@ -0,0 +1,209 @@
+package main
+
+// fontGlyphs holds the glyphs of the bitmap font used by --output png, 8 pixels wide and 16 pixels high.
+// Each row stores the coverage of its pixels from left to right using 2 bits per pixel, from 0 (empty) to 3 (full).
+// The glyphs were rasterized from DejaVu Sans Mono, block elements, box drawing and braille characters are drawn by pngGlyphMask.
+// They are distributed under the Bitstream Vera license found in LICENSE-DejaVu
+var fontGlyphs = map[rune][16]uint16{
+	'!':      {0x0000, 0x0000, 0x0040, 0x02c0, 0x02c0, 0x02c0, 0x02c0, 0x0280, 0x0180, 0x0140, 0x0000, 0x02c0, 0x0180, 0x0000, 0x0000, 0x0000},
+	'"':      {0x0000, 0x0000, 0x0410, 0x0930, 0x0930, 0x0930, 0x0520, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'#':      {0x0000, 0x0000, 0x0000, 0x024c, 0x034c, 0x1769, 0x6fba, 0x0930, 0x0d30, 0xfffe, 0x1890, 0x24c0, 0x2080, 0x0000, 0x0000, 0x0000},
+	'$':      {0x0000, 0x0000, 0x0040, 0x0080, 0x0bf8, 0x2884, 0x2880, 0x1e80, 0x06f8, 0x009d, 0x0089, 0x259c, 0x1ae4, 0x0080, 0x0040, 0x0000},
+	'%':      {0x0000, 0x0000, 0x0000, 0x2e00, 0x9240, 0x8140, 0x6b46, 0x15a4, 0x1a54, 0x50da, 0x0183, 0x00d7, 0x0068, 0x0000, 0x0000, 0x0000},
+	'&':      {0x0000, 0x0000, 0x0150, 0x0ea0, 0x1800, 0x1c00, 0x0e00, 0x2b41, 0x61c3, 0xa0a3, 0xa03e, 0x386d, 0x1be6, 0x0000, 0x0000, 0x0000},
+	'\'':     {0x0000, 0x0000, 0x0040, 0x0280, 0x0280, 0x0280, 0x0140, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'(':      {0x0000, 0x0000, 0x0050, 0x0090, 0x01c0, 0x0280, 0x0340, 0x0340, 0x0300, 0x0340, 0x0340, 0x0280, 0x01c0, 0x0090, 0x0050, 0x0000},
+	')':      {0x0000, 0x0000, 0x0500, 0x0300, 0x0280, 0x01c0, 0x00d0, 0x00d0, 0x00d0, 0x00d0, 0x00d0, 0x01c0, 0x0280, 0x0300, 0x0500, 0x0000},
+	'*':      {0x0000, 0x0000, 0x0040, 0x0180, 0x29a8, 0x03d0, 0x1aa4, 0x1184, 0x0140, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'+':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0180, 0x0180, 0x0180, 0x7ffe, 0x0180, 0x0180, 0x0180, 0x0000, 0x0000, 0x0000, 0x0000},
+	',':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0040, 0x02c0, 0x02c0, 0x0340, 0x0200, 0x0000},
+	'-':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0690, 0x0690, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'.':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0140, 0x02c0, 0x0280, 0x0000, 0x0000, 0x0000},
+	'/':      {0x0000, 0x0000, 0x0004, 0x001c, 0x0034, 0x0070, 0x00a0, 0x01c0, 0x0280, 0x0700, 0x0a00, 0x0c00, 0x2800, 0x3400, 0x0000, 0x0000},
+	'0':      {0x0000, 0x0000, 0x0140, 0x0eb4, 0x282c, 0x341d, 0x340d, 0x36cd, 0x354d, 0x340d, 0x281c, 0x1d78, 0x07e0, 0x0000, 0x0000, 0x0000},
+	'1':      {0x0000, 0x0000, 0x0140, 0x1fd0, 0x05d0, 0x00d0, 0x00d0, 0x00d0, 0x00d0, 0x00d0, 0x00d0, 0x05e4, 0x1aa9, 0x0000, 0x0000, 0x0000},
+	'2':      {0x0000, 0x0000, 0x0540, 0x2eb4, 0x102c, 0x001c, 0x0028, 0x0074, 0x00d0, 0x0380, 0x0e00, 0x2d54, 0x2aa8, 0x0000, 0x0000, 0x0000},
+	'3':      {0x0000, 0x0000, 0x0540, 0x2eb4, 0x002c, 0x001c, 0x0078, 0x07e0, 0x0028, 0x000d, 0x001d, 0x2578, 0x2fe0, 0x0000, 0x0000, 0x0000},
+	'4':      {0x0000, 0x0000, 0x0010, 0x00b4, 0x01b4, 0x0374, 0x0934, 0x1834, 0x3434, 0x7ab9, 0x1579, 0x0034, 0x0024, 0x0000, 0x0000, 0x0000},
+	'5':      {0x0000, 0x0000, 0x0550, 0x2ff4, 0x2800, 0x2800, 0x2f90, 0x1578, 0x001c, 0x001c, 0x001c, 0x2578, 0x2fe0, 0x0000, 0x0000, 0x0000},
+	'6':      {0x0000, 0x0000, 0x0150, 0x0ba8, 0x1c00, 0x3400, 0x36e0, 0x3d6c, 0x380d, 0x340d, 0x280d, 0x1d2c, 0x07e0, 0x0000, 0x0000, 0x0000},
+	'7':      {0x0000, 0x0000, 0x1554, 0x3ffc, 0x0028, 0x0034, 0x0070, 0x00a0, 0x00d0, 0x01c0, 0x0380, 0x0700, 0x0600, 0x0000, 0x0000, 0x0000},
+	'8':      {0x0000, 0x0000, 0x0150, 0x1eb8, 0x281c, 0x381c, 0x1d28, 0x0bf0, 0x282c, 0x340d, 0x340d, 0x292c, 0x0be4, 0x0000, 0x0000, 0x0000},
+	'9':      {0x0000, 0x0000, 0x0140, 0x1eb4, 0x342c, 0x341c, 0x341d, 0x382d, 0x1ead, 0x014d, 0x001c, 0x1574, 0x1b90, 0x0000, 0x0000, 0x0000},
+	':':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0140, 0x02c0, 0x0180, 0x0000, 0x0000, 0x0140, 0x02c0, 0x0280, 0x0000, 0x0000, 0x0000},
+	';':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0140, 0x02c0, 0x0180, 0x0000, 0x0000, 0x0040, 0x02c0, 0x02c0, 0x0340, 0x0200, 0x0000},
+	'<':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0005, 0x01b9, 0x1f80, 0x7800, 0x1b90, 0x00bd, 0x0005, 0x0000, 0x0000, 0x0000, 0x0000},
+	'=':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x6aa9, 0x6aa9, 0x0000, 0x6aa9, 0x1555, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'>':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x6000, 0x2e40, 0x01b8, 0x001e, 0x02f4, 0x6e40, 0x5000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'?':      {0x0000, 0x0000, 0x0150, 0x1eb4, 0x102c, 0x002c, 0x0074, 0x01d0, 0x0280, 0x0280, 0x0000, 0x0280, 0x0240, 0x0000, 0x0000, 0x0000},
+	'@':      {0x0000, 0x0000, 0x0000, 0x01a4, 0x1e5d, 0x3406, 0x60a7, 0x929b, 0x9703, 0x9603, 0x934b, 0x61fa, 0x3400, 0x1d04, 0x02b8, 0x0000},
+	'A':      {0x0000, 0x0000, 0x0140, 0x03d0, 0x07a0, 0x0a60, 0x0d30, 0x0c34, 0x1c28, 0x2ffc, 0x340d, 0x700a, 0x6006, 0x0000, 0x0000, 0x0000},
+	'B':      {0x0000, 0x0000, 0x1540, 0x3ff8, 0x381d, 0x380d, 0x382c, 0x3ff4, 0x381d, 0x380a, 0x380a, 0x396d, 0x2aa4, 0x0000, 0x0000, 0x0000},
+	'C':      {0x0000, 0x0000, 0x0154, 0x0bad, 0x1d00, 0x2800, 0x3400, 0x3400, 0x3400, 0x3800, 0x2800, 0x0e59, 0x02f8, 0x0000, 0x0000, 0x0000},
+	'D':      {0x0000, 0x0000, 0x1500, 0x3fe0, 0x3428, 0x341c, 0x340d, 0x340d, 0x340d, 0x341d, 0x342c, 0x39b4, 0x2a90, 0x0000, 0x0000, 0x0000},
+	'E':      {0x0000, 0x0000, 0x0554, 0x2ffd, 0x2800, 0x2800, 0x2800, 0x2ffc, 0x2800, 0x2800, 0x2800, 0x2954, 0x1aa9, 0x0000, 0x0000, 0x0000},
+	'F':      {0x0000, 0x0000, 0x0554, 0x1ffd, 0x1c00, 0x1c00, 0x1c00, 0x1ffc, 0x1c00, 0x1c00, 0x1c00, 0x1c00, 0x1800, 0x0000, 0x0000, 0x0000},
+	'G':      {0x0000, 0x0000, 0x0150, 0x0bac, 0x2c04, 0x3400, 0x7400, 0x7014, 0x706d, 0x340d, 0x380d, 0x1d1d, 0x06f8, 0x0000, 0x0000, 0x0000},
+	'H':      {0x0000, 0x0000, 0x1004, 0x340d, 0x340d, 0x340d, 0x340d, 0x3ffd, 0x340d, 0x340d, 0x340d, 0x340d, 0x2409, 0x0000, 0x0000, 0x0000},
+	'I':      {0x0000, 0x0000, 0x0554, 0x2ff8, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x16d4, 0x1aa8, 0x0000, 0x0000, 0x0000},
+	'J':      {0x0000, 0x0000, 0x0150, 0x07f4, 0x0034, 0x0034, 0x0034, 0x0034, 0x0034, 0x0034, 0x0034, 0x64b0, 0x2f90, 0x0000, 0x0000, 0x0000},
+	'K':      {0x0000, 0x0000, 0x1001, 0x341d, 0x3474, 0x34d0, 0x3780, 0x3f80, 0x39d0, 0x34b0, 0x3438, 0x341d, 0x240a, 0x0000, 0x0000, 0x0000},
+	'L':      {0x0000, 0x0000, 0x0400, 0x2800, 0x2800, 0x2800, 0x2800, 0x2800, 0x2800, 0x2800, 0x2800, 0x2d55, 0x1aa9, 0x0000, 0x0000, 0x0000},
+	'M':      {0x0000, 0x0000, 0x1004, 0x781e, 0x7c2e, 0x793a, 0x766a, 0x739a, 0x72ca, 0x700a, 0x700a, 0x700a, 0x6005, 0x0000, 0x0000, 0x0000},
+	'N':      {0x0000, 0x0000, 0x1404, 0x3c0d, 0x3d0d, 0x3a0d, 0x374d, 0x368d, 0x34cd, 0x349d, 0x346d, 0x343d, 0x2419, 0x0000, 0x0000, 0x0000},
+	'O':      {0x0000, 0x0000, 0x0150, 0x1eb4, 0x281c, 0x340d, 0x340d, 0x740e, 0x740e, 0x340d, 0x381c, 0x1d68, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'P':      {0x0000, 0x0000, 0x0540, 0x2ff8, 0x281d, 0x280a, 0x280e, 0x296d, 0x2ea4, 0x2800, 0x2800, 0x2800, 0x1400, 0x0000, 0x0000, 0x0000},
+	'Q':      {0x0000, 0x0000, 0x0150, 0x1eb4, 0x281c, 0x340d, 0x340d, 0x740e, 0x740e, 0x340d, 0x381d, 0x1d68, 0x0bf0, 0x0038, 0x0004, 0x0000},
+	'R':      {0x0000, 0x0000, 0x1540, 0x3ff4, 0x342c, 0x341c, 0x341c, 0x3ab4, 0x3aa0, 0x3428, 0x341c, 0x340a, 0x2406, 0x0000, 0x0000, 0x0000},
+	'S':      {0x0000, 0x0000, 0x0150, 0x1eb8, 0x3800, 0x3400, 0x3900, 0x1be0, 0x006c, 0x000d, 0x000d, 0x256c, 0x2be4, 0x0000, 0x0000, 0x0000},
+	'T':      {0x0000, 0x0000, 0x1555, 0xbfff, 0x02c0, 0x02c0, 0x02c0, 0x02c0, 0x02c0, 0x02c0, 0x02c0, 0x02c0, 0x0180, 0x0000, 0x0000, 0x0000},
+	'U':      {0x0000, 0x0000, 0x1004, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x2d6c, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'V':      {0x0000, 0x0000, 0x1001, 0x700a, 0x340d, 0x281c, 0x2828, 0x1c28, 0x0d34, 0x0a70, 0x07a0, 0x03d0, 0x0280, 0x0000, 0x0000, 0x0000},
+	'W':      {0x0000, 0x0000, 0x4001, 0xd003, 0xa007, 0xa147, 0x62ca, 0x739a, 0x3659, 0x3a6d, 0x3d3c, 0x2c2c, 0x1818, 0x0000, 0x0000, 0x0000},
+	'X':      {0x0000, 0x0000, 0x1001, 0x340d, 0x1c28, 0x0a74, 0x03e0, 0x02c0, 0x07a0, 0x0a74, 0x1c28, 0x340d, 0x6006, 0x0000, 0x0000, 0x0000},
+	'Y':      {0x0000, 0x0000, 0x5001, 0x700e, 0x281c, 0x0d34, 0x0aa0, 0x03d0, 0x0280, 0x0280, 0x0280, 0x0280, 0x0180, 0x0000, 0x0000, 0x0000},
+	'Z':      {0x0000, 0x0000, 0x1555, 0x2ffe, 0x001d, 0x0038, 0x00b0, 0x01d0, 0x0380, 0x0b00, 0x1d00, 0x2955, 0x2aaa, 0x0000, 0x0000, 0x0000},
+	'[':      {0x0000, 0x0000, 0x02a0, 0x0390, 0x0340, 0x0340, 0x0340, 0x0340, 0x0340, 0x0340, 0x0340, 0x0340, 0x0340, 0x0390, 0x02a0, 0x0000},
+	'\\':     {0x0000, 0x0000, 0x1000, 0x3400, 0x2800, 0x0d00, 0x0a00, 0x0340, 0x0280, 0x00c0, 0x00a0, 0x0070, 0x0028, 0x001c, 0x0000, 0x0000},
+	']':      {0x0000, 0x0000, 0x0680, 0x01c0, 0x00c0, 0x00c0, 0x00c0, 0x00c0, 0x00c0, 0x00c0, 0x00c0, 0x00c0, 0x00c0, 0x05c0, 0x0680, 0x0000},
+	'^':      {0x0000, 0x0000, 0x0040, 0x03d0, 0x0e74, 0x281c, 0x1005, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'_':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0xaaaa},
+	'`':      {0x0000, 0x0400, 0x0a00, 0x0280, 0x0040, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'a':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x1be0, 0x1428, 0x000c, 0x0bfc, 0x380c, 0x301c, 0x387c, 0x1b98, 0x0000, 0x0000, 0x0000},
+	'b':      {0x0000, 0x0000, 0x1400, 0x2800, 0x2800, 0x2ae0, 0x2d2c, 0x280d, 0x280d, 0x280d, 0x280d, 0x2d2c, 0x26e0, 0x0000, 0x0000, 0x0000},
+	'c':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x02f8, 0x0e48, 0x1c00, 0x2800, 0x2800, 0x1c00, 0x0e04, 0x02f8, 0x0000, 0x0000, 0x0000},
+	'd':      {0x0000, 0x0000, 0x0008, 0x001c, 0x001c, 0x0b9c, 0x297c, 0x341c, 0x301c, 0x301c, 0x341c, 0x297c, 0x0b98, 0x0000, 0x0000, 0x0000},
+	'e':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x06e0, 0x1d2c, 0x340d, 0x3aad, 0x3554, 0x3400, 0x1d18, 0x06f8, 0x0000, 0x0000, 0x0000},
+	'f':      {0x0000, 0x0000, 0x0068, 0x01d4, 0x0280, 0x2be8, 0x1694, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0140, 0x0000, 0x0000, 0x0000},
+	'g':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0b98, 0x2d7c, 0x341c, 0x301c, 0x301c, 0x341c, 0x2d7c, 0x0a9c, 0x001c, 0x1974, 0x0a90},
+	'h':      {0x0000, 0x0000, 0x1400, 0x2800, 0x2800, 0x2ae4, 0x2d28, 0x281c, 0x281c, 0x281c, 0x281c, 0x281c, 0x2418, 0x0000, 0x0000, 0x0000},
+	'i':      {0x0000, 0x0000, 0x0180, 0x0180, 0x0000, 0x1a80, 0x06c0, 0x01c0, 0x01c0, 0x01c0, 0x01c0, 0x16d4, 0x2aa9, 0x0000, 0x0000, 0x0000},
+	'j':      {0x0000, 0x0000, 0x0080, 0x00d0, 0x0000, 0x0a90, 0x05d0, 0x00d0, 0x00d0, 0x00d0, 0x00d0, 0x00d0, 0x00d0, 0x00d0, 0x16c0, 0x1a00},
+	'k':      {0x0000, 0x0000, 0x1800, 0x1c00, 0x1c00, 0x1c18, 0x1c74, 0x1dd0, 0x1fc0, 0x1da0, 0x1c34, 0x1c1d, 0x180a, 0x0000, 0x0000, 0x0000},
+	'l':      {0x0000, 0x0000, 0x2a40, 0x1740, 0x0340, 0x0340, 0x0340, 0x0340, 0x0340, 0x0340, 0x0340, 0x0294, 0x00a8, 0x0000, 0x0000, 0x0000},
+	'm':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x6b78, 0x76d9, 0x718a, 0x718a, 0x718a, 0x718a, 0x718a, 0x6185, 0x0000, 0x0000, 0x0000},
+	'n':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x26e4, 0x2d28, 0x281c, 0x281c, 0x281c, 0x281c, 0x281c, 0x2418, 0x0000, 0x0000, 0x0000},
+	'o':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0be0, 0x2d28, 0x340d, 0x340d, 0x340d, 0x340d, 0x2d28, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'p':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x26e0, 0x2d2c, 0x280d, 0x280d, 0x280d, 0x280d, 0x2d2c, 0x2ae0, 0x2800, 0x2800, 0x1400},
+	'q':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0b98, 0x2d7c, 0x341c, 0x341c, 0x341c, 0x341c, 0x2d6c, 0x0bdc, 0x000c, 0x000c, 0x0008},
+	'r':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x057e, 0x0b95, 0x0b00, 0x0a00, 0x0a00, 0x0a00, 0x0a00, 0x0500, 0x0000, 0x0000, 0x0000},
+	's':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0be4, 0x1d14, 0x2800, 0x1f90, 0x01b8, 0x001c, 0x1428, 0x1be0, 0x0000, 0x0000, 0x0000},
+	't':      {0x0000, 0x0000, 0x0000, 0x0300, 0x0300, 0x2ba8, 0x1754, 0x0300, 0x0300, 0x0300, 0x0300, 0x0394, 0x01a8, 0x0000, 0x0000, 0x0000},
+	'u':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x2418, 0x281c, 0x281c, 0x281c, 0x281c, 0x281c, 0x1d6c, 0x0b98, 0x0000, 0x0000, 0x0000},
+	'v':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x2009, 0x340c, 0x2818, 0x1c24, 0x0930, 0x06a0, 0x03d0, 0x0280, 0x0000, 0x0000, 0x0000},
+	'w':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x9002, 0x9007, 0x6146, 0x72ca, 0x339d, 0x2a6c, 0x2d38, 0x1824, 0x0000, 0x0000, 0x0000},
+	'x':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x2409, 0x1c28, 0x0aa0, 0x02c0, 0x03d0, 0x0a70, 0x2828, 0x2409, 0x0000, 0x0000, 0x0000},
+	'y':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x2009, 0x240d, 0x1c1c, 0x0d24, 0x0a30, 0x07a0, 0x03d0, 0x02c0, 0x0280, 0x1b00, 0x1900},
+	'z':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x1aa8, 0x0568, 0x0070, 0x01d0, 0x0340, 0x0a00, 0x1d54, 0x1aa8, 0x0000, 0x0000, 0x0000},
+	'{':      {0x0000, 0x0000, 0x0064, 0x01d4, 0x01c0, 0x0280, 0x0280, 0x0280, 0x1f00, 0x0680, 0x0280, 0x0280, 0x0180, 0x01c0, 0x00b8, 0x0000},
+	'|':      {0x0000, 0x0000, 0x0180, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280},
+	'}':      {0x0000, 0x0000, 0x1a00, 0x0780, 0x0280, 0x0280, 0x0280, 0x01c0, 0x00b8, 0x01d0, 0x0280, 0x0280, 0x0280, 0x0280, 0x2e40, 0x0000},
+	'~':      {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x1500, 0x7aea, 0x0054, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00a1': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0180, 0x02c0, 0x0000, 0x0140, 0x0180, 0x0280, 0x02c0, 0x02c0, 0x02c0, 0x02c0, 0x0040},
+	'\u00a2': {0x0000, 0x0000, 0x0000, 0x0050, 0x0050, 0x02f8, 0x0a54, 0x1c50, 0x2850, 0x2850, 0x1c50, 0x0a54, 0x02f8, 0x0050, 0x0050, 0x0000},
+	'\u00a3': {0x0000, 0x0000, 0x0054, 0x02e9, 0x0700, 0x0700, 0x0b00, 0x1ba4, 0x1ba4, 0x0b00, 0x0b00, 0x1b55, 0x2aa9, 0x0000, 0x0000, 0x0000},
+	'\u00a4': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0004, 0x1ee8, 0x0924, 0x0c18, 0x0924, 0x1aac, 0x0004, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00a5': {0x0000, 0x0000, 0x5001, 0x700e, 0x281c, 0x1d34, 0x2eb9, 0x17d4, 0x2ae9, 0x1694, 0x0280, 0x0280, 0x0180, 0x0000, 0x0000, 0x0000},
+	'\u00a6': {0x0000, 0x0000, 0x0000, 0x0180, 0x0280, 0x0280, 0x0280, 0x0280, 0x0040, 0x0000, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0000},
+	'\u00a7': {0x0000, 0x0000, 0x0150, 0x0ea4, 0x1c00, 0x0e00, 0x0ed0, 0x2878, 0x281c, 0x0b58, 0x01f0, 0x0034, 0x0434, 0x0fe0, 0x0000, 0x0000},
+	'\u00a8': {0x0000, 0x0000, 0x0920, 0x0960, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00a9': {0x0000, 0x0000, 0x0000, 0x0140, 0x1968, 0x6696, 0x8c02, 0x9801, 0x9802, 0x9a52, 0x2559, 0x0aa4, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00aa': {0x0000, 0x0000, 0x0140, 0x0570, 0x0164, 0x0d64, 0x0c24, 0x0aa4, 0x0550, 0x0aa4, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00ab': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x030c, 0x1d74, 0x34d0, 0x28a0, 0x0a1c, 0x0104, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00ac': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x7ffe, 0x000a, 0x000a, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00ad': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0690, 0x0690, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00ae': {0x0000, 0x0000, 0x0000, 0x0140, 0x1968, 0x6696, 0x8932, 0x8aa1, 0x8992, 0x9922, 0x2409, 0x0aa4, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00af': {0x0000, 0x0000, 0x06a0, 0x06a0, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00b0': {0x0000, 0x0000, 0x0140, 0x0aa0, 0x0c24, 0x0920, 0x0290, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00b1': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0180, 0x0180, 0x7ffe, 0x1694, 0x0180, 0x0140, 0x1555, 0x6aa9, 0x0000, 0x0000, 0x0000},
+	'\u00b2': {0x0000, 0x0000, 0x0540, 0x05a0, 0x0070, 0x0090, 0x0240, 0x0aa0, 0x0550, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00b3': {0x0000, 0x0000, 0x0540, 0x0570, 0x0070, 0x01a0, 0x0034, 0x05b0, 0x0140, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00b4': {0x0000, 0x0010, 0x0060, 0x0180, 0x0100, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00b5': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x2418, 0x281c, 0x281c, 0x281c, 0x281c, 0x281c, 0x2d6d, 0x2ada, 0x2400, 0x2400, 0x1400},
+	'\u00b6': {0x0000, 0x0000, 0x0154, 0x1fec, 0x3f8c, 0x7f8c, 0x3f8c, 0x2f8c, 0x028c, 0x018c, 0x018c, 0x018c, 0x018c, 0x018c, 0x0000, 0x0000},
+	'\u00b7': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x02c0, 0x02c0, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00b8': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0040, 0x0090, 0x0590, 0x0140},
+	'\u00b9': {0x0000, 0x0000, 0x0140, 0x0a80, 0x0180, 0x0180, 0x0180, 0x06e0, 0x0150, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00ba': {0x0000, 0x0000, 0x0140, 0x0a70, 0x1828, 0x1818, 0x0c24, 0x07a0, 0x0550, 0x0aa4, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00bb': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x2490, 0x0d34, 0x034d, 0x0a2c, 0x2860, 0x1040, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00bc': {0x0000, 0x1400, 0x5c00, 0x0c00, 0x0c00, 0x0c00, 0x2e40, 0x15a9, 0x6a40, 0x4038, 0x00a8, 0x0198, 0x0368, 0x0168, 0x0014, 0x0000},
+	'\u00bd': {0x0000, 0x1400, 0x5c00, 0x0c00, 0x0c00, 0x0c00, 0x2e40, 0x15a9, 0x6a50, 0x41a8, 0x0009, 0x0018, 0x0060, 0x01d4, 0x01a9, 0x0000},
+	'\u00be': {0x0000, 0x1900, 0x1640, 0x0240, 0x0a40, 0x0180, 0x6740, 0x1569, 0x6a40, 0x4038, 0x00a8, 0x0198, 0x0368, 0x0168, 0x0014, 0x0000},
+	'\u00bf': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0180, 0x01c0, 0x0000, 0x01c0, 0x01c0, 0x0340, 0x0e00, 0x2800, 0x2804, 0x1eb8, 0x0150},
+	'\u00c0': {0x0340, 0x0180, 0x0140, 0x03d0, 0x07a0, 0x0a60, 0x0d30, 0x0c34, 0x1c28, 0x2ffc, 0x340d, 0x700a, 0x6006, 0x0000, 0x0000, 0x0000},
+	'\u00c1': {0x0090, 0x0140, 0x0140, 0x03d0, 0x07a0, 0x0a60, 0x0d30, 0x0c34, 0x1c28, 0x2ffc, 0x340d, 0x700a, 0x6006, 0x0000, 0x0000, 0x0000},
+	'\u00c2': {0x0290, 0x0520, 0x0140, 0x03d0, 0x07a0, 0x0a60, 0x0d30, 0x0c34, 0x1c28, 0x2ffc, 0x340d, 0x700a, 0x6006, 0x0000, 0x0000, 0x0000},
+	'\u00c3': {0x0a64, 0x0850, 0x0140, 0x03d0, 0x07a0, 0x0a60, 0x0d30, 0x0c34, 0x1c28, 0x2ffc, 0x340d, 0x700a, 0x6006, 0x0000, 0x0000, 0x0000},
+	'\u00c4': {0x0960, 0x0920, 0x0140, 0x03d0, 0x07a0, 0x0a60, 0x0d30, 0x0c34, 0x1c28, 0x2ffc, 0x340d, 0x700a, 0x6006, 0x0000, 0x0000, 0x0000},
+	'\u00c5': {0x06d0, 0x0930, 0x0960, 0x03d0, 0x07a0, 0x0a60, 0x0d30, 0x0c34, 0x1c28, 0x2ffc, 0x340d, 0x700a, 0x6006, 0x0000, 0x0000, 0x0000},
+	'\u00c6': {0x0000, 0x0000, 0x0155, 0x07fe, 0x09a0, 0x0ca0, 0x1ca0, 0x28be, 0x34a0, 0x3fe0, 0x75a0, 0xa0a5, 0x90aa, 0x0000, 0x0000, 0x0000},
+	'\u00c7': {0x0000, 0x0000, 0x0154, 0x0bad, 0x1d00, 0x2800, 0x3400, 0x3400, 0x3400, 0x3800, 0x2800, 0x0e59, 0x02f8, 0x0020, 0x0170, 0x0150},
+	'\u00c8': {0x0240, 0x0080, 0x0554, 0x2ffd, 0x2800, 0x2800, 0x2800, 0x2ffc, 0x2800, 0x2800, 0x2800, 0x2954, 0x1aa9, 0x0000, 0x0000, 0x0000},
+	'\u00c9': {0x0090, 0x0180, 0x0554, 0x2ffd, 0x2800, 0x2800, 0x2800, 0x2ffc, 0x2800, 0x2800, 0x2800, 0x2954, 0x1aa9, 0x0000, 0x0000, 0x0000},
+	'\u00ca': {0x0290, 0x0520, 0x0554, 0x2ffd, 0x2800, 0x2800, 0x2800, 0x2ffc, 0x2800, 0x2800, 0x2800, 0x2954, 0x1aa9, 0x0000, 0x0000, 0x0000},
+	'\u00cb': {0x0924, 0x0520, 0x0554, 0x2ffd, 0x2800, 0x2800, 0x2800, 0x2ffc, 0x2800, 0x2800, 0x2800, 0x2954, 0x1aa9, 0x0000, 0x0000, 0x0000},
+	'\u00cc': {0x0340, 0x0180, 0x0554, 0x2ff8, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x16d4, 0x1aa8, 0x0000, 0x0000, 0x0000},
+	'\u00cd': {0x0090, 0x0140, 0x0554, 0x2ff8, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x16d4, 0x1aa8, 0x0000, 0x0000, 0x0000},
+	'\u00ce': {0x0290, 0x0520, 0x0554, 0x2ff8, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x16d4, 0x1aa8, 0x0000, 0x0000, 0x0000},
+	'\u00cf': {0x0960, 0x0920, 0x0554, 0x2ff8, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x0280, 0x16d4, 0x1aa8, 0x0000, 0x0000, 0x0000},
+	'\u00d0': {0x0000, 0x0000, 0x1500, 0x3fe0, 0x3428, 0x341c, 0x340d, 0xff0d, 0x340d, 0x341d, 0x342c, 0x39b4, 0x2a90, 0x0000, 0x0000, 0x0000},
+	'\u00d1': {0x0a64, 0x0450, 0x1404, 0x3c0d, 0x3d0d, 0x3a0d, 0x374d, 0x368d, 0x34cd, 0x349d, 0x346d, 0x343d, 0x2419, 0x0000, 0x0000, 0x0000},
+	'\u00d2': {0x0340, 0x0180, 0x0150, 0x1eb4, 0x281c, 0x340d, 0x340d, 0x740e, 0x740e, 0x340d, 0x381c, 0x1d68, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00d3': {0x0090, 0x0140, 0x0150, 0x1eb4, 0x281c, 0x340d, 0x340d, 0x740e, 0x740e, 0x340d, 0x381c, 0x1d68, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00d4': {0x0290, 0x0520, 0x0150, 0x1eb4, 0x281c, 0x340d, 0x340d, 0x740e, 0x740e, 0x340d, 0x381c, 0x1d68, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00d5': {0x0a64, 0x0850, 0x0150, 0x1eb4, 0x281c, 0x340d, 0x340d, 0x740e, 0x740e, 0x340d, 0x381c, 0x1d68, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00d6': {0x0960, 0x0920, 0x0150, 0x1eb4, 0x281c, 0x340d, 0x340d, 0x740e, 0x740e, 0x340d, 0x381c, 0x1d68, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00d7': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x1004, 0x2c2c, 0x0bb0, 0x03d0, 0x0bb0, 0x2c2c, 0x1004, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00d8': {0x0000, 0x0000, 0x0141, 0x1eba, 0x282c, 0x342d, 0x349d, 0x758e, 0x760e, 0x3d0d, 0x381c, 0x3d68, 0x9be0, 0x0000, 0x0000, 0x0000},
+	'\u00d9': {0x0340, 0x0180, 0x1004, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x2d6c, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00da': {0x0090, 0x0140, 0x1004, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x2d6c, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00db': {0x0290, 0x0520, 0x1004, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x2d6c, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00dc': {0x0960, 0x0920, 0x1004, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x340d, 0x2d6c, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00dd': {0x0090, 0x0140, 0x5001, 0x700e, 0x281c, 0x0d34, 0x0aa0, 0x03d0, 0x0280, 0x0280, 0x0280, 0x0280, 0x0180, 0x0000, 0x0000, 0x0000},
+	'\u00de': {0x0000, 0x0000, 0x0400, 0x2800, 0x2e50, 0x2ebd, 0x280e, 0x280a, 0x280e, 0x2efc, 0x2950, 0x2800, 0x1800, 0x0000, 0x0000, 0x0000},
+	'\u00df': {0x0000, 0x0000, 0x0690, 0x1d74, 0x281c, 0x28a4, 0x2980, 0x29c0, 0x28b4, 0x281d, 0x280a, 0x291a, 0x26f8, 0x0000, 0x0000, 0x0000},
+	'\u00e0': {0x0000, 0x0400, 0x0a00, 0x0280, 0x0040, 0x1be0, 0x1428, 0x000c, 0x0bfc, 0x380c, 0x301c, 0x387c, 0x1b98, 0x0000, 0x0000, 0x0000},
+	'\u00e1': {0x0000, 0x0010, 0x0060, 0x0180, 0x0100, 0x1be0, 0x1428, 0x000c, 0x0bfc, 0x380c, 0x301c, 0x387c, 0x1b98, 0x0000, 0x0000, 0x0000},
+	'\u00e2': {0x0000, 0x0040, 0x02c0, 0x0a60, 0x0410, 0x1be0, 0x1428, 0x000c, 0x0bfc, 0x380c, 0x301c, 0x387c, 0x1b98, 0x0000, 0x0000, 0x0000},
+	'\u00e3': {0x0000, 0x0000, 0x0a54, 0x08a0, 0x0000, 0x1be0, 0x1428, 0x000c, 0x0bfc, 0x380c, 0x301c, 0x387c, 0x1b98, 0x0000, 0x0000, 0x0000},
+	'\u00e4': {0x0000, 0x0000, 0x0920, 0x0960, 0x0000, 0x1be0, 0x1428, 0x000c, 0x0bfc, 0x380c, 0x301c, 0x387c, 0x1b98, 0x0000, 0x0000, 0x0000},
+	'\u00e5': {0x0040, 0x06a0, 0x0830, 0x06a0, 0x0140, 0x1be0, 0x1428, 0x000c, 0x0bfc, 0x380c, 0x301c, 0x387c, 0x1b98, 0x0000, 0x0000, 0x0000},
+	'\u00e6': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x2e7d, 0x12d6, 0x0183, 0x1aeb, 0x7695, 0x9180, 0xa2d1, 0x2e7e, 0x0000, 0x0000, 0x0000},
+	'\u00e7': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x02f8, 0x0e48, 0x1c00, 0x2800, 0x2800, 0x1c00, 0x0e04, 0x02f8, 0x0020, 0x0170, 0x0150},
+	'\u00e8': {0x0000, 0x0400, 0x0600, 0x0280, 0x0040, 0x06e0, 0x1d2c, 0x340d, 0x3aad, 0x3554, 0x3400, 0x1d18, 0x06f8, 0x0000, 0x0000, 0x0000},
+	'\u00e9': {0x0000, 0x0010, 0x0060, 0x01c0, 0x0000, 0x06e0, 0x1d2c, 0x340d, 0x3aad, 0x3554, 0x3400, 0x1d18, 0x06f8, 0x0000, 0x0000, 0x0000},
+	'\u00ea': {0x0000, 0x0040, 0x02d0, 0x0660, 0x0410, 0x06e0, 0x1d2c, 0x340d, 0x3aad, 0x3554, 0x3400, 0x1d18, 0x06f8, 0x0000, 0x0000, 0x0000},
+	'\u00eb': {0x0000, 0x0000, 0x0520, 0x0924, 0x0000, 0x06e0, 0x1d2c, 0x340d, 0x3aad, 0x3554, 0x3400, 0x1d18, 0x06f8, 0x0000, 0x0000, 0x0000},
+	'\u00ec': {0x0000, 0x0400, 0x0a00, 0x0280, 0x0040, 0x1a80, 0x06c0, 0x01c0, 0x01c0, 0x01c0, 0x01c0, 0x16d4, 0x2aa9, 0x0000, 0x0000, 0x0000},
+	'\u00ed': {0x0000, 0x0010, 0x0060, 0x0180, 0x0100, 0x1a80, 0x06c0, 0x01c0, 0x01c0, 0x01c0, 0x01c0, 0x16d4, 0x2aa9, 0x0000, 0x0000, 0x0000},
+	'\u00ee': {0x0000, 0x0040, 0x02c0, 0x0a60, 0x0410, 0x1a80, 0x06c0, 0x01c0, 0x01c0, 0x01c0, 0x01c0, 0x16d4, 0x2aa9, 0x0000, 0x0000, 0x0000},
+	'\u00ef': {0x0000, 0x0000, 0x0520, 0x0924, 0x0000, 0x1a80, 0x06c0, 0x01c0, 0x01c0, 0x01c0, 0x01c0, 0x16d4, 0x2aa9, 0x0000, 0x0000, 0x0000},
+	'\u00f0': {0x0000, 0x0000, 0x0600, 0x07a4, 0x19d0, 0x06b0, 0x1e68, 0x281c, 0x340d, 0x340d, 0x340d, 0x2d28, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00f1': {0x0000, 0x0000, 0x0a54, 0x08a0, 0x0000, 0x26e4, 0x2d28, 0x281c, 0x281c, 0x281c, 0x281c, 0x281c, 0x2418, 0x0000, 0x0000, 0x0000},
+	'\u00f2': {0x0000, 0x0400, 0x0a00, 0x0280, 0x0040, 0x0be0, 0x2d28, 0x340d, 0x340d, 0x340d, 0x340d, 0x2d28, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00f3': {0x0000, 0x0010, 0x0060, 0x0180, 0x0100, 0x0be0, 0x2d28, 0x340d, 0x340d, 0x340d, 0x340d, 0x2d28, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00f4': {0x0000, 0x0040, 0x02c0, 0x0a60, 0x0410, 0x0be0, 0x2d28, 0x340d, 0x340d, 0x340d, 0x340d, 0x2d28, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00f5': {0x0000, 0x0000, 0x0a54, 0x08a0, 0x0000, 0x0be0, 0x2d28, 0x340d, 0x340d, 0x340d, 0x340d, 0x2d28, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00f6': {0x0000, 0x0000, 0x0920, 0x0960, 0x0000, 0x0be0, 0x2d28, 0x340d, 0x340d, 0x340d, 0x340d, 0x2d28, 0x0be0, 0x0000, 0x0000, 0x0000},
+	'\u00f7': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x02c0, 0x0180, 0x0000, 0x7ffe, 0x0000, 0x02c0, 0x0180, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u00f8': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0bea, 0x2d2c, 0x346d, 0x348d, 0x364d, 0x3d0d, 0x2d28, 0x6be0, 0x0000, 0x0000, 0x0000},
+	'\u00f9': {0x0000, 0x0400, 0x0a00, 0x0280, 0x0040, 0x2418, 0x281c, 0x281c, 0x281c, 0x281c, 0x281c, 0x1d6c, 0x0b98, 0x0000, 0x0000, 0x0000},
+	'\u00fa': {0x0000, 0x0010, 0x0060, 0x0180, 0x0100, 0x2418, 0x281c, 0x281c, 0x281c, 0x281c, 0x281c, 0x1d6c, 0x0b98, 0x0000, 0x0000, 0x0000},
+	'\u00fb': {0x0000, 0x0040, 0x02c0, 0x0a60, 0x0410, 0x2418, 0x281c, 0x281c, 0x281c, 0x281c, 0x281c, 0x1d6c, 0x0b98, 0x0000, 0x0000, 0x0000},
+	'\u00fc': {0x0000, 0x0000, 0x0920, 0x0960, 0x0000, 0x2418, 0x281c, 0x281c, 0x281c, 0x281c, 0x281c, 0x1d6c, 0x0b98, 0x0000, 0x0000, 0x0000},
+	'\u00fd': {0x0000, 0x0010, 0x0060, 0x0180, 0x0100, 0x2009, 0x240d, 0x1c1c, 0x0d24, 0x0a30, 0x07a0, 0x03d0, 0x02c0, 0x0280, 0x1b00, 0x1900},
+	'\u00fe': {0x0000, 0x0000, 0x2400, 0x2800, 0x2800, 0x2ae0, 0x2d2c, 0x280d, 0x280d, 0x280d, 0x280d, 0x2d2c, 0x2ae0, 0x2800, 0x2800, 0x1400},
+	'\u00ff': {0x0000, 0x0000, 0x0920, 0x0960, 0x0000, 0x2009, 0x240d, 0x1c1c, 0x0d24, 0x0a30, 0x07a0, 0x03d0, 0x02c0, 0x0280, 0x1b00, 0x1900},
+	'\u2013': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0xaaaa, 0xaaaa, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u2014': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0xaaaa, 0xaaaa, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u2018': {0x0000, 0x0000, 0x0050, 0x01c0, 0x02c0, 0x0380, 0x0140, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u2019': {0x0000, 0x0000, 0x0090, 0x01d0, 0x01c0, 0x0280, 0x0100, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u201c': {0x0000, 0x0000, 0x0514, 0x0d28, 0x1d74, 0x2cb4, 0x0410, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u201d': {0x0000, 0x0000, 0x0928, 0x0e3c, 0x0d34, 0x1870, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u2022': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0140, 0x07e0, 0x0ff0, 0x0bf0, 0x0280, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u2026': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x1145, 0xb6ce, 0x668a, 0x0000, 0x0000, 0x0000},
+	'\u2190': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x1800, 0x7aa9, 0x7aa9, 0x1800, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u2191': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0140, 0x07e0, 0x09a0, 0x0180, 0x0180, 0x0180, 0x0180, 0x0140, 0x0000, 0x0000, 0x0000},
+	'\u2192': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0018, 0x6aae, 0x6aae, 0x0018, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
+	'\u2193': {0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0140, 0x0180, 0x0180, 0x0180, 0x0180, 0x09a0, 0x07e0, 0x0180, 0x0000, 0x0000, 0x0000},
+}
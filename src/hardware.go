@@ -63,7 +63,7 @@ func GetMonitorResolution() []string {
 	if GetDisplayProtocol() != "" {
 		err := glfw.Init()
 		if err != nil {
-			panic(err)
+			return nil
 		}
 		for _, monitor := range glfw.GetMonitors() {
 			mode := monitor.GetVideoMode()
@@ -73,3 +73,15 @@ func GetMonitorResolution() []string {
 	}
 	return monitors
 }
+
+// CheckDisplay returns an error if monitor information cannot be retrieved through GLFW
+func CheckDisplay() error {
+	if GetDisplayProtocol() == "" {
+		return fmt.Errorf("XDG_SESSION_TYPE is neither 'x11' nor 'wayland'")
+	}
+	if err := glfw.Init(); err != nil {
+		return err
+	}
+	glfw.Terminate()
+	return nil
+}
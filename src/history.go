@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type HistoryEntry struct {
+	Time    time.Time
+	Metrics map[string]uint64
+}
+
+type History []HistoryEntry
+
+var sparklineChars = []rune("▁▂▃▄▅▆▇█")
+
+func GetHistoryFilePath() string {
+	if config.HistoryFile != "" && config.HistoryFile != "auto" {
+		return config.HistoryFile
+	}
+	// Follow the XDG base directory specification for state files
+	if stateDir := os.Getenv("XDG_STATE_HOME"); stateDir != "" {
+		return path.Join(stateDir, "stormfetch/history")
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return path.Join(homeDir, ".local/state/stormfetch/history")
+}
+
+func ReadHistory(filepath string) (History, error) {
+	bytes, err := os.ReadFile(filepath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var history History
+	for _, line := range strings.Split(string(bytes), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		timestamp, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		entry := HistoryEntry{
+			Time:    time.Unix(timestamp, 0),
+			Metrics: make(map[string]uint64),
+		}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			key, err = url.PathUnescape(key)
+			if err != nil {
+				continue
+			}
+			num, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			entry.Metrics[key] = num
+		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
+func WriteHistory(filepath string, history History, maxEntries int) error {
+	if maxEntries > 0 && len(history) > maxEntries {
+		history = history[len(history)-maxEntries:]
+	}
+	builder := strings.Builder{}
+	for _, entry := range history {
+		keys := make([]string, 0, len(entry.Metrics))
+		for key := range entry.Metrics {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		builder.WriteString(strconv.FormatInt(entry.Time.Unix(), 10))
+		for _, key := range keys {
+			builder.WriteString(fmt.Sprintf(" %s=%d", escapeHistoryKey(key), entry.Metrics[key]))
+		}
+		builder.WriteString("\n")
+	}
+	if err := os.MkdirAll(path.Dir(filepath), 0755); err != nil {
+		return err
+	}
+	// Write to a temporary file first so an interrupted run cannot truncate the history
+	if err := os.WriteFile(filepath+".tmp", []byte(builder.String()), 0644); err != nil {
+		return err
+	}
+	return os.Rename(filepath+".tmp", filepath)
+}
+
+// escapeHistoryKey percent-encodes the characters of a metric key that would split its field, such as the spaces of a mountpoint
+func escapeHistoryKey(key string) string {
+	builder := strings.Builder{}
+	for _, r := range key {
+		if !unicode.IsSpace(r) && r != '=' && r != '%' {
+			builder.WriteRune(r)
+			continue
+		}
+		for _, b := range []byte(string(r)) {
+			builder.WriteString(fmt.Sprintf("%%%02X", b))
+		}
+	}
+	return builder.String()
+}
+
+// Delta returns the change of a metric between the oldest entry recorded in the last given amount of days and the latest entry
+func (history History) Delta(key string, days int) (int64, bool) {
+	if len(history) < 2 {
+		return 0, false
+	}
+	latest := history[len(history)-1]
+	current, ok := latest.Metrics[key]
+	if !ok {
+		return 0, false
+	}
+	cutoff := latest.Time.Add(-time.Duration(days) * 24 * time.Hour)
+	for _, entry := range history[:len(history)-1] {
+		if entry.Time.Before(cutoff) {
+			continue
+		}
+		if value, ok := entry.Metrics[key]; ok {
+			return int64(current) - int64(value), true
+		}
+	}
+	return 0, false
+}
+
+// Sparkline returns the last values of a metric drawn using block characters
+func (history History) Sparkline(key string, length int) string {
+	var values []uint64
+	for i := len(history) - 1; i >= 0 && len(values) < length; i-- {
+		if value, ok := history[i].Metrics[key]; ok {
+			values = append([]uint64{value}, values...)
+		}
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	minValue, maxValue := values[0], values[0]
+	for _, value := range values {
+		minValue = min(minValue, value)
+		maxValue = max(maxValue, value)
+	}
+	ret := ""
+	for _, value := range values {
+		index := 0
+		if maxValue != minValue {
+			index = int((value - minValue) * uint64(len(sparklineChars)-1) / (maxValue - minValue))
+		}
+		ret += string(sparklineChars[index])
+	}
+	return ret
+}
+
+func RecordHistory(memory *Memory, partitions []partition, packages []PackageCount) (History, error) {
+	entry := HistoryEntry{
+		Time:    time.Now(),
+		Metrics: make(map[string]uint64),
+	}
+	if memory != nil {
+		entry.Metrics["mem_used"] = uint64(memory.MemTotal - memory.MemAvailable)
+	}
+	for _, part := range partitions {
+		entry.Metrics["partition:"+part.MountPoint] = part.UsedSize
+	}
+	total := 0
+	for _, count := range packages {
+		entry.Metrics["packages:"+count.Manager] = uint64(count.Count)
+		total += count.Count
+	}
+	if len(packages) != 0 {
+		entry.Metrics["packages"] = uint64(total)
+	}
+
+	filepath := GetHistoryFilePath()
+	if filepath == "" {
+		return nil, fmt.Errorf("could not determine history file path")
+	}
+	history, err := ReadHistory(filepath)
+	if err != nil {
+		return nil, err
+	}
+	history = append(history, entry)
+	if err := WriteHistory(filepath, history, config.HistoryMaxEntries); err != nil {
+		return history, err
+	}
+	return history, nil
+}
+
+func SetHistoryVariables(env map[string]string, history History, partitions []partition) {
+	formatDelta := func(delta int64) string {
+		if delta < 0 {
+			return "-" + strconv.FormatInt(-delta, 10)
+		}
+		return "+" + strconv.FormatInt(delta, 10)
+	}
+	formatBytesDelta := func(delta int64) string {
+		if delta < 0 {
+			return "-" + FormatBytes(uint64(-delta))
+		}
+		return "+" + FormatBytes(uint64(delta))
+	}
+	// The first window is also exposed without its amount of days so fetch scripts do not depend on history_delta_days
+	if len(config.HistoryDeltaDays) != 0 {
+		env["HISTORY_DELTA_DAYS"] = strconv.Itoa(config.HistoryDeltaDays[0])
+	}
+	setTrend := func(prefix, key string, format func(int64) string) {
+		for i, days := range config.HistoryDeltaDays {
+			if delta, ok := history.Delta(key, days); ok {
+				env[fmt.Sprintf("%s_DELTA_%dD", prefix, days)] = format(delta)
+				if i == 0 {
+					env[prefix+"_DELTA"] = format(delta)
+				}
+			}
+		}
+		if sparkline := history.Sparkline(key, config.HistorySparklineLength); sparkline != "" {
+			env[prefix+"_SPARKLINE"] = sparkline
+		}
+	}
+
+	setTrend("MEM_USED", "mem_used", formatDelta)
+	setTrend("PACKAGES", "packages", formatDelta)
+	for i, part := range partitions {
+		setTrend("PARTITION"+strconv.Itoa(i+1)+"_USED", "partition:"+part.MountPoint, formatBytesDelta)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHistoryRoundTrip(t *testing.T) {
+	history := History{
+		{Time: time.Unix(1700000000, 0), Metrics: map[string]uint64{
+			"mem_used":                         1024,
+			"partition:/":                      10,
+			"partition:/run/media/u/My Disk":   20,
+			"partition:/mnt/a=b":               30,
+			"partition:/mnt/100%":              40,
+			"partition:/mnt/tab\tand\nnewline": 50,
+			"packages:dpkg":                    500,
+		}},
+		{Time: time.Unix(1700086400, 0), Metrics: map[string]uint64{"partition:/run/media/u/My Disk": 25}},
+	}
+	filepath := path.Join(t.TempDir(), "history")
+	if err := WriteHistory(filepath, history, 0); err != nil {
+		t.Fatal(err)
+	}
+	bytes, err := os.ReadFile(filepath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, line := range strings.Split(strings.TrimSpace(string(bytes)), "\n") {
+		if fields := strings.Fields(line); len(fields) != len(history[i].Metrics)+1 {
+			t.Errorf("line %d has %d fields, want %d: %q", i+1, len(fields), len(history[i].Metrics)+1, line)
+		}
+	}
+	read, err := ReadHistory(filepath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, history) {
+		t.Errorf("ReadHistory() = %+v, want %+v", read, history)
+	}
+}
+
+func TestReadHistory(t *testing.T) {
+	filepath := path.Join(t.TempDir(), "history")
+	content := "1700000000 mem_used=1 partition:/=2\n" +
+		"not-a-timestamp mem_used=3\n" +
+		"\n" +
+		"1700000001 invalid partition:/home=abc partition:/run/media/u/My%20Disk=4 partition:/bad%zz=5\n"
+	if err := os.WriteFile(filepath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	history, err := ReadHistory(filepath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := History{
+		{Time: time.Unix(1700000000, 0), Metrics: map[string]uint64{"mem_used": 1, "partition:/": 2}},
+		{Time: time.Unix(1700000001, 0), Metrics: map[string]uint64{"partition:/run/media/u/My Disk": 4}},
+	}
+	if !reflect.DeepEqual(history, want) {
+		t.Errorf("ReadHistory() = %+v, want %+v", history, want)
+	}
+	if history, err := ReadHistory(path.Join(t.TempDir(), "missing")); history != nil || err != nil {
+		t.Errorf("ReadHistory() of a missing file = %v, %v, want nil, nil", history, err)
+	}
+}
+
+func TestSetHistoryVariables(t *testing.T) {
+	savedDays := config.HistoryDeltaDays
+	config.HistoryDeltaDays = []int{3, 30}
+	t.Cleanup(func() {
+		config.HistoryDeltaDays = savedDays
+	})
+
+	now := time.Unix(1700000000, 0)
+	day := 24 * time.Hour
+	history := History{
+		{Time: now.Add(-20 * day), Metrics: map[string]uint64{"packages": 100, "partition:/data": 1 << 30}},
+		{Time: now.Add(-2 * day), Metrics: map[string]uint64{"packages": 110, "partition:/data": 3 << 30}},
+		{Time: now, Metrics: map[string]uint64{"packages": 105, "partition:/data": 4 << 30}},
+	}
+	env := make(map[string]string)
+	SetHistoryVariables(env, history, []partition{{MountPoint: "/data"}})
+
+	want := map[string]string{
+		"HISTORY_DELTA_DAYS":        "3",
+		"PACKAGES_DELTA":            "-5",
+		"PACKAGES_DELTA_3D":         "-5",
+		"PACKAGES_DELTA_30D":        "+5",
+		"PARTITION1_USED_DELTA":     "+" + FormatBytes(1<<30),
+		"PARTITION1_USED_DELTA_3D":  "+" + FormatBytes(1<<30),
+		"PARTITION1_USED_DELTA_30D": "+" + FormatBytes(3<<30),
+	}
+	for key, value := range want {
+		if env[key] != value {
+			t.Errorf("%s = %q, want %q", key, env[key], value)
+		}
+	}
+	if _, ok := env["MEM_USED_DELTA"]; ok {
+		t.Errorf("MEM_USED_DELTA is set without memory history")
+	}
+	if env["PACKAGES_SPARKLINE"] == "" || env["PARTITION1_USED_SPARKLINE"] == "" {
+		t.Errorf("sparklines are not set: %v", env)
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ImageProtocols = []string{"auto", "kitty", "sixel", "iterm2", "none"}
+
+// LogoImage holds an image logo along with the amount of terminal cells reserved for it
+type LogoImage struct {
+	Image    image.Image
+	Width    int
+	Height   int
+	Sequence string
+}
+
+// DetectImageProtocol returns the image protocol supported by the terminal, or "none" if it could not be detected
+func DetectImageProtocol() string {
+	term := os.Getenv("TERM")
+	termProgram := os.Getenv("TERM_PROGRAM")
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty" || term == "xterm-ghostty" || termProgram == "ghostty":
+		return "kitty"
+	case termProgram == "iTerm.app" || termProgram == "WezTerm" || os.Getenv("LC_TERMINAL") == "iTerm2":
+		return "iterm2"
+	case strings.Contains(term, "sixel") || slices.Contains([]string{"foot", "foot-extra", "mlterm", "contour", "yaft-256color"}, term):
+		return "sixel"
+	}
+	return "none"
+}
+
+// GetLogoImage loads the image logo set in the config, returning nil if no image is set or it cannot be shown in this terminal
+func GetLogoImage() (*LogoImage, error) {
+	if config.LogoImage == "" || !ColorEnabled() || !IsTerminal(os.Stdout) {
+		return nil, nil
+	}
+	if !slices.Contains(ImageProtocols, config.ImageProtocol) {
+		return nil, fmt.Errorf("invalid image protocol '%s', expected one of: %s", config.ImageProtocol, strings.Join(ImageProtocols, ", "))
+	}
+	protocol := config.ImageProtocol
+	if protocol == "auto" {
+		protocol = DetectImageProtocol()
+	}
+	if protocol == "none" {
+		return nil, nil
+	}
+
+	file, err := os.Open(config.LogoImage)
+	if err != nil {
+		return nil, fmt.Errorf("Could not open logo image: %s", err)
+	}
+	defer file.Close()
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("Could not decode logo image %s: %s", config.LogoImage, err)
+	}
+
+	// Keep the aspect ratio of the image using the size of a cell in pixels, assuming cells twice as high as wide if unknown
+	cellWidth, cellHeight := 8, 16
+	if size, ok := GetTerminalSize(); ok && size.PixelWidth != 0 && size.PixelHeight != 0 && size.Rows != 0 {
+		cellWidth, cellHeight = size.PixelWidth/size.Columns, size.PixelHeight/size.Rows
+	}
+	bounds := img.Bounds()
+	logo := &LogoImage{Image: img, Width: max(config.LogoWidth, 1)}
+	logo.Height = max((bounds.Dy()*logo.Width*cellWidth+bounds.Dx()*cellHeight/2)/(bounds.Dx()*cellHeight), 1)
+
+	switch protocol {
+	case "kitty":
+		logo.Sequence, err = logo.encodeKitty()
+	case "iterm2":
+		logo.Sequence, err = logo.encodeITerm2()
+	case "sixel":
+		logo.Sequence = logo.encodeSixel(logo.Width*cellWidth, logo.Height*cellHeight)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return logo, nil
+}
+
+// Placeholder returns a block of spaces taking the cells reserved for the image
+func (logo *LogoImage) Placeholder() string {
+	lines := make([]string, logo.Height)
+	for i := range lines {
+		lines[i] = strings.Repeat(" ", logo.Width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DrawImage appends the sequences moving the cursor from the end of a frame to the art placement, drawing the image and moving back
+func (logo *LogoImage) DrawImage(frame string, placement Placement) string {
+	up := strings.Count(frame, "\n") - placement.Row
+	sequence := "\0337"
+	if up > 0 {
+		sequence += "\033[" + strconv.Itoa(up) + "A"
+	}
+	sequence += "\r"
+	if placement.Column > 0 {
+		sequence += "\033[" + strconv.Itoa(placement.Column) + "C"
+	}
+	return frame + sequence + logo.Sequence + "\0338"
+}
+
+func (logo *LogoImage) encodePNG() ([]byte, error) {
+	buffer := bytes.Buffer{}
+	if err := png.Encode(&buffer, logo.Image); err != nil {
+		return nil, fmt.Errorf("Could not encode logo image: %s", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// encodeKitty returns the image transmitted and displayed using the kitty graphics protocol, split into chunks of 4096 bytes
+func (logo *LogoImage) encodeKitty() (string, error) {
+	encoded, err := logo.encodePNG()
+	if err != nil {
+		return "", err
+	}
+	data := base64.StdEncoding.EncodeToString(encoded)
+	builder := strings.Builder{}
+	for first := true; first || data != ""; first = false {
+		chunk := data[:min(len(data), 4096)]
+		data = data[len(chunk):]
+		more := 0
+		if data != "" {
+			more = 1
+		}
+		if first {
+			// Scale the image to the reserved cells without moving the cursor or replying
+			builder.WriteString(fmt.Sprintf("\033_Ga=T,f=100,q=2,C=1,c=%d,r=%d,m=%d;%s\033\\", logo.Width, logo.Height, more, chunk))
+		} else {
+			builder.WriteString(fmt.Sprintf("\033_Gm=%d;%s\033\\", more, chunk))
+		}
+	}
+	return builder.String(), nil
+}
+
+// encodeITerm2 returns the image as an iTerm2 inline image
+func (logo *LogoImage) encodeITerm2() (string, error) {
+	encoded, err := logo.encodePNG()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("\033]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=1:%s\a",
+		len(encoded), logo.Width, logo.Height, base64.StdEncoding.EncodeToString(encoded)), nil
+}
+
+// encodeSixel returns the image scaled to the given size in pixels as sixel graphics, using the colors of the 256-color cube
+func (logo *LogoImage) encodeSixel(width, height int) string {
+	bounds := logo.Image.Bounds()
+	// Sample the image at the target size, using -1 for transparent pixels
+	pixels := make([]int, width*height)
+	var used []int
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, a := logo.Image.At(bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height).RGBA()
+			if a < 0x8000 {
+				pixels[y*width+x] = -1
+				continue
+			}
+			// Undo alpha premultiplication
+			r, g, b = r*0xffff/a, g*0xffff/a, b*0xffff/a
+			index := nearestCubeLevel(uint8(r>>8))*36 + nearestCubeLevel(uint8(g>>8))*6 + nearestCubeLevel(uint8(b>>8))
+			pixels[y*width+x] = index
+			if !slices.Contains(used, index) {
+				used = append(used, index)
+			}
+		}
+	}
+
+	builder := strings.Builder{}
+	// Leave pixels that are not drawn transparent
+	builder.WriteString(fmt.Sprintf("\033P0;1;0q\"1;1;%d;%d", width, height))
+	for _, index := range used {
+		r, g, b := TermColor{Mode: Color256, Index: uint8(16 + index)}.RGB()
+		builder.WriteString(fmt.Sprintf("#%d;2;%d;%d;%d", index, int(r)*100/255, int(g)*100/255, int(b)*100/255))
+	}
+	for top := 0; top < height; top += 6 {
+		for _, index := range used {
+			row := make([]byte, width)
+			found := false
+			for x := 0; x < width; x++ {
+				bits := 0
+				for dy := 0; dy < 6 && top+dy < height; dy++ {
+					if pixels[(top+dy)*width+x] == index {
+						bits |= 1 << dy
+					}
+				}
+				row[x] = byte(63 + bits)
+				found = found || bits != 0
+			}
+			if !found {
+				continue
+			}
+			builder.WriteString("#" + strconv.Itoa(index))
+			// Run-length encode repeated sixels
+			for x := 0; x < width; {
+				run := 1
+				for x+run < width && row[x+run] == row[x] {
+					run++
+				}
+				if run > 3 {
+					builder.WriteString("!" + strconv.Itoa(run) + string(row[x]))
+				} else {
+					builder.WriteString(strings.Repeat(string(row[x]), run))
+				}
+				x += run
+			}
+			builder.WriteString("$")
+		}
+		builder.WriteString("-")
+	}
+	builder.WriteString("\033\\")
+	return builder.String()
+}
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ImportFormats = []string{"auto", "neofetch", "fastfetch"}
+
+var importFormat = "auto"
+var importColors = ""
+var importColorsJSON = ""
+var importOutput = ""
+var importForce = false
+
+var neofetchPlaceholderRegex = regexp.MustCompile(`\$\{c([0-9])\}`)
+var fastfetchPlaceholderRegex = regexp.MustCompile(`\$([1-9])`)
+var neofetchCaseRegex = regexp.MustCompile(`^\s*([^()]+)\)\s*$`)
+var neofetchColorsRegex = regexp.MustCompile(`^\s*set_colors\s+(.*)$`)
+var quotedRegex = regexp.MustCompile(`"([^"]+)"`)
+var jsonCommentRegex = regexp.MustCompile(`(?m)^\s*//.*$|/\*[\s\S]*?\*/`)
+
+// ImportedAscii holds an art converted to the stormfetch format along with the ID it should be saved as
+type ImportedAscii struct {
+	ID    string
+	Ascii string
+}
+
+func setupAsciiImportFlags(flags *flag.FlagSet) {
+	flags.StringVar(&importFormat, "format", importFormat, "Format of the imported art ("+strings.Join(ImportFormats, ", ")+")")
+	flags.StringVar(&importColors, "colors", "", "Comma separated colors of the placeholders, as given to neofetch's set_colors or fastfetch's logo.color")
+	flags.StringVar(&importColorsJSON, "colors-json", "", "Read the colors from the logo.color object of a fastfetch JSON config")
+	flags.StringVar(&importOutput, "o", "", "Write a single art to the given file, or the arts of a neofetch script to the given directory")
+	flags.BoolVar(&importForce, "force", false, "Overwrite the existing arts when importing the arts of a neofetch script")
+}
+
+func runAsciiImport(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Error: expected a single file to import")
+		return 2
+	}
+	if !slices.Contains(ImportFormats, importFormat) {
+		fmt.Fprintf(os.Stderr, "Error: invalid format '%s', expected one of: %s\n", importFormat, strings.Join(ImportFormats, ", "))
+		return 2
+	}
+	bytes, err := os.ReadFile(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	content := string(bytes)
+
+	var colors []string
+	if importColors != "" {
+		colors = strings.FieldsFunc(importColors, func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+	}
+	if importColorsJSON != "" {
+		if colors, err = readFastfetchColors(importColorsJSON); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			return 1
+		}
+	}
+
+	format := importFormat
+	if format == "auto" {
+		format = DetectImportFormat(content)
+	}
+	// The neofetch script holds the arts of every distribution
+	collection := format == "neofetch" && strings.Contains(content, "ascii_data")
+	var arts []ImportedAscii
+	switch {
+	case collection:
+		arts, err = ImportNeofetchScript(content)
+	case format == "neofetch":
+		var ascii string
+		ascii, err = ImportNeofetchAscii(content, colors)
+		arts = []ImportedAscii{{ID: strings.TrimSuffix(path.Base(args[0]), path.Ext(args[0])), Ascii: ascii}}
+	case format == "fastfetch":
+		var ascii string
+		ascii, err = ImportFastfetchAscii(content, colors)
+		arts = []ImportedAscii{{ID: strings.TrimSuffix(path.Base(args[0]), path.Ext(args[0])), Ascii: ascii}}
+	default:
+		err = fmt.Errorf("could not detect the format of %s, set it with --format", args[0])
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	if !collection {
+		if importOutput == "" {
+			fmt.Print(arts[0].Ascii)
+			return 0
+		}
+		if err := os.WriteFile(importOutput, []byte(arts[0].Ascii), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	// Collections are written into the user ascii art directory unless another one is given
+	dir := importOutput
+	if dir == "" {
+		userConfDir, err := os.UserConfigDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			return 1
+		}
+		dir = path.Join(userConfDir, "stormfetch/ascii/")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	// Keep the arts the user may have customized unless asked to replace them
+	var skipped []string
+	for _, art := range arts {
+		file := path.Join(dir, art.ID)
+		if _, err := os.Stat(file); err == nil && !importForce {
+			skipped = append(skipped, art.ID)
+			continue
+		}
+		if err := os.WriteFile(file, []byte(art.Ascii), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			return 1
+		}
+	}
+	fmt.Printf("Imported %d ascii arts into %s\n", len(arts)-len(skipped), dir)
+	if len(skipped) != 0 {
+		fmt.Fprintf(os.Stderr, "Warning: skipped %d existing ascii arts, use --force to overwrite them: %s\n", len(skipped), strings.Join(skipped, ", "))
+	}
+	return 0
+}
+
+// DetectImportFormat guesses the format of an art from its placeholders
+func DetectImportFormat(content string) string {
+	switch {
+	case strings.Contains(content, "ascii_data") || neofetchPlaceholderRegex.MatchString(content):
+		return "neofetch"
+	case fastfetchPlaceholderRegex.MatchString(content):
+		return "fastfetch"
+	}
+	return ""
+}
+
+// buildAscii prepends the '#/' header made of the given colors to an art, checking that every color is valid
+func buildAscii(colors []string, ascii string) (string, error) {
+	for i, color := range colors {
+		if _, err := ParseColorSpec(color); err != nil {
+			return "", fmt.Errorf("invalid color C%d '%s': %s", i+1, color, err)
+		}
+	}
+	ascii = strings.Trim(ascii, "\n")
+	if strings.Contains(ascii, "${C") && !strings.HasSuffix(ascii, "${C0}") {
+		ascii += "${C0}"
+	}
+	if len(colors) == 0 {
+		return ascii + "\n", nil
+	}
+	return "#/" + strings.Join(colors, ";") + "\n" + ascii + "\n", nil
+}
+
+// checkPlaceholders returns an error if an art uses more color slots than stormfetch supports
+func checkPlaceholders(matches [][]string) error {
+	for _, match := range matches {
+		if slot, _ := strconv.Atoi(match[1]); slot > 6 {
+			return fmt.Errorf("color %d is used but stormfetch only supports 6 colors", slot)
+		}
+	}
+	return nil
+}
+
+// convertNeofetchColor converts a color given to neofetch's set_colors, which renders its art bold
+func convertNeofetchColor(color string) (string, error) {
+	if color == "fg" {
+		return "bold", nil
+	}
+	if index, err := strconv.Atoi(color); err != nil || index < 0 || index > 255 {
+		return "", fmt.Errorf("invalid neofetch color '%s'", color)
+	}
+	return color, nil
+}
+
+// ImportNeofetchAscii converts an art using neofetch's ${c1} placeholders, given the colors passed to set_colors
+func ImportNeofetchAscii(content string, colors []string) (string, error) {
+	if err := checkPlaceholders(neofetchPlaceholderRegex.FindAllStringSubmatch(content, -1)); err != nil {
+		return "", err
+	}
+	var converted []string
+	for _, color := range colors {
+		spec, err := convertNeofetchColor(color)
+		if err != nil {
+			return "", err
+		}
+		converted = append(converted, spec)
+	}
+	// neofetch prints its art using printf %b, which turns escaped backslashes into single ones
+	content = strings.ReplaceAll(content, "\\\\", "\\")
+	return buildAscii(converted, neofetchPlaceholderRegex.ReplaceAllString(content, "$${C$1}"))
+}
+
+// ImportNeofetchScript extracts every art of the get_distro_ascii function of the neofetch script, named after the first pattern of its case
+func ImportNeofetchScript(content string) ([]ImportedAscii, error) {
+	var arts []ImportedAscii
+	var id string
+	var colors []string
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if match := neofetchCaseRegex.FindStringSubmatch(line); match != nil {
+			id, colors = "", nil
+			if quoted := quotedRegex.FindStringSubmatch(match[1]); quoted != nil {
+				id = strings.ReplaceAll(strings.ToLower(strings.Trim(quoted[1], "* ")), " ", "-")
+			}
+			continue
+		}
+		if match := neofetchColorsRegex.FindStringSubmatch(line); match != nil {
+			colors = strings.Fields(match[1])
+			continue
+		}
+		if !strings.Contains(line, "ascii_data <<") {
+			continue
+		}
+		var art []string
+		for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "EOF"; i++ {
+			art = append(art, lines[i])
+		}
+		if id == "" || slices.ContainsFunc(arts, func(art ImportedAscii) bool { return art.ID == id }) {
+			continue
+		}
+		ascii, err := ImportNeofetchAscii(strings.Join(art, "\n"), colors)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", id, err)
+		}
+		arts = append(arts, ImportedAscii{ID: id, Ascii: ascii})
+	}
+	if len(arts) == 0 {
+		return nil, fmt.Errorf("no ascii art found")
+	}
+	return arts, nil
+}
+
+// convertFastfetchColor converts a fastfetch color such as 'bright_blue', 'bold_#ff0000' or '38;5;123' into a color slot
+func convertFastfetchColor(color string) (string, error) {
+	state := SGRState{}
+	prefixes := map[string]func(){
+		"bold":      func() { state.Bold = true },
+		"dim":       func() { state.Dim = true },
+		"italic":    func() { state.Italic = true },
+		"underline": func() { state.Underline = true },
+		"reset":     func() {},
+	}
+	bright := false
+	words := strings.Split(strings.ToLower(color), "_")
+	for _, word := range words[:len(words)-1] {
+		if apply, ok := prefixes[word]; ok {
+			apply()
+		} else if word == "bright" || word == "light" {
+			bright = true
+		} else {
+			return "", fmt.Errorf("invalid fastfetch color '%s'", color)
+		}
+	}
+	last := words[len(words)-1]
+	switch {
+	case strings.Trim(last, "0123456789;") == "":
+		// Raw SGR parameters
+		state.Apply(last)
+	default:
+		if bright {
+			last = "bright-" + last
+		}
+		foreground, err := ParseColor(last)
+		if err != nil {
+			return "", fmt.Errorf("invalid fastfetch color '%s'", color)
+		}
+		state.Foreground = foreground
+	}
+	return FormatColorSpec(state), nil
+}
+
+// readFastfetchColors reads the logo.color object of a fastfetch JSON config, which may contain comments
+func readFastfetchColors(file string) ([]string, error) {
+	bytes, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var config struct {
+		Logo struct {
+			Color map[string]string `json:"color"`
+		} `json:"logo"`
+	}
+	if err := json.Unmarshal([]byte(jsonCommentRegex.ReplaceAllString(string(bytes), "")), &config); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %s", file, err)
+	}
+	var colors []string
+	for i := 1; i <= 9; i++ {
+		if color, ok := config.Logo.Color[strconv.Itoa(i)]; ok {
+			colors = append(colors, color)
+		} else {
+			break
+		}
+	}
+	return colors, nil
+}
+
+// ImportFastfetchAscii converts an art using fastfetch's $1 placeholders, given the colors of the logo.color object
+func ImportFastfetchAscii(content string, colors []string) (string, error) {
+	if err := checkPlaceholders(fastfetchPlaceholderRegex.FindAllStringSubmatch(content, -1)); err != nil {
+		return "", err
+	}
+	var converted []string
+	for _, color := range colors {
+		spec, err := convertFastfetchColor(color)
+		if err != nil {
+			return "", err
+		}
+		converted = append(converted, spec)
+	}
+	return buildAscii(converted, fastfetchPlaceholderRegex.ReplaceAllString(content, "$${C$1}"))
+}
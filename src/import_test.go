@@ -0,0 +1,194 @@
+package main
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestDetectImportFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"neofetch placeholders", "${c1}  /\\\n${c2} /  \\", "neofetch"},
+		{"neofetch script", "read -rd '' ascii_data <<'EOF'", "neofetch"},
+		{"fastfetch placeholders", "$1  /\\\n$2 /  \\", "fastfetch"},
+		{"stormfetch placeholders", "${C1}  /\\", ""},
+		{"plain art", "  /\\\n /  \\", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := DetectImportFormat(test.content); got != test.want {
+				t.Errorf("DetectImportFormat(%q) = %q, want %q", test.content, got, test.want)
+			}
+		})
+	}
+}
+
+func TestImportNeofetchAscii(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		colors  []string
+		want    string
+		wantErr bool
+	}{
+		{"colors", "${c1}  /\\\\\n${c2} /  \\\\", []string{"4", "fg"}, "#/4;bold\n${C1}  /\\\n${C2} /  \\${C0}\n", false},
+		{"without colors", "\n${c1}arch\n\n", nil, "${C1}arch${C0}\n", false},
+		{"already reset", "${c1}arch${c0}", []string{"6"}, "#/6\n${C1}arch${C0}\n", false},
+		{"plain art", "  /\\\\\n", nil, "  /\\\n", false},
+		{"too many colors", "${c1}a${c7}b", nil, "", true},
+		{"color out of range", "${c1}a", []string{"256"}, "", true},
+		{"color name", "${c1}a", []string{"red"}, "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ascii, err := ImportNeofetchAscii(test.content, test.colors)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ImportNeofetchAscii(%q, %q) error = %v, want error %t", test.content, test.colors, err, test.wantErr)
+			}
+			if ascii != test.want {
+				t.Errorf("ImportNeofetchAscii(%q, %q) = %q, want %q", test.content, test.colors, ascii, test.want)
+			}
+		})
+	}
+}
+
+func TestImportNeofetchScript(t *testing.T) {
+	script := `get_distro_ascii() {
+    case $(trim "$ascii_distro") in
+        "AIX"*)
+            set_colors 2 7
+            read -rd '' ascii_data <<'EOF'
+${c1}aix
+${c2}art
+EOF
+        ;;
+
+        "Arch Linux"* | "arch"*)
+            set_colors 6 6 7 1
+            read -rd '' ascii_data <<'EOF'
+${c1}arch
+EOF
+        ;;
+
+        "AIX"*)
+            read -rd '' ascii_data <<'EOF'
+duplicate
+EOF
+        ;;
+
+        *)
+            read -rd '' ascii_data <<'EOF'
+fallback
+EOF
+        ;;
+    esac
+}`
+	arts, err := ImportNeofetchScript(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ImportedAscii{
+		{ID: "aix", Ascii: "#/2;7\n${C1}aix\n${C2}art${C0}\n"},
+		{ID: "arch-linux", Ascii: "#/6;6;7;1\n${C1}arch${C0}\n"},
+	}
+	if !reflect.DeepEqual(arts, want) {
+		t.Errorf("ImportNeofetchScript() = %q, want %q", arts, want)
+	}
+
+	if _, err := ImportNeofetchScript("#!/usr/bin/env bash\n"); err == nil {
+		t.Errorf("ImportNeofetchScript() of a script without arts returned no error")
+	}
+	invalid := "\"AIX\"*)\nset_colors red\nread -rd '' ascii_data <<'EOF'\n${c1}aix\nEOF\n"
+	if _, err := ImportNeofetchScript(invalid); err == nil {
+		t.Errorf("ImportNeofetchScript() of an art with an invalid color returned no error")
+	}
+}
+
+func TestConvertFastfetchColor(t *testing.T) {
+	tests := []struct {
+		color   string
+		want    string
+		wantErr bool
+	}{
+		{"blue", "blue", false},
+		{"bright_red", "bright-red", false},
+		{"light_green", "bright-green", false},
+		{"bold_bright_red", "bright-red bold", false},
+		{"italic_#ff0000", "#ff0000 italic", false},
+		{"38;5;123", "#87ffff", false},
+		{"1;34", "blue bold", false},
+		{"reset_default", "default", false},
+		{"purple", "", true},
+		{"shiny_red", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.color, func(t *testing.T) {
+			spec, err := convertFastfetchColor(test.color)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("convertFastfetchColor(%q) error = %v, want error %t", test.color, err, test.wantErr)
+			}
+			if spec != test.want {
+				t.Errorf("convertFastfetchColor(%q) = %q, want %q", test.color, spec, test.want)
+			}
+		})
+	}
+}
+
+func TestImportFastfetchAscii(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		colors  []string
+		want    string
+		wantErr bool
+	}{
+		{"colors", "$1  /\\\n$2 /  \\", []string{"blue", "bold_bright_red"}, "#/blue;bright-red bold\n${C1}  /\\\n${C2} /  \\${C0}\n", false},
+		{"without colors", "$1arch\n", nil, "${C1}arch${C0}\n", false},
+		{"too many colors", "$1a$7b", nil, "", true},
+		{"invalid color", "$1a", []string{"purple"}, "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ascii, err := ImportFastfetchAscii(test.content, test.colors)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ImportFastfetchAscii(%q, %q) error = %v, want error %t", test.content, test.colors, err, test.wantErr)
+			}
+			if ascii != test.want {
+				t.Errorf("ImportFastfetchAscii(%q, %q) = %q, want %q", test.content, test.colors, ascii, test.want)
+			}
+		})
+	}
+}
+
+func TestReadFastfetchColors(t *testing.T) {
+	file := path.Join(t.TempDir(), "config.jsonc")
+	content := `{
+  // The colors of the logo
+  "logo": {
+    /* 4 is ignored since 3 is missing */
+    "color": {"1": "blue", "2": "bold_red", "4": "green"}
+  }
+}`
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	colors, err := readFastfetchColors(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"blue", "bold_red"}; !reflect.DeepEqual(colors, want) {
+		t.Errorf("readFastfetchColors() = %q, want %q", colors, want)
+	}
+
+	if err := os.WriteFile(file, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readFastfetchColors(file); err == nil {
+		t.Errorf("readFastfetchColors() of an invalid config returned no error")
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var AsciiPositions = []string{"left", "right", "top", "bottom", "none"}
+var VerticalAlignments = []string{"top", "center"}
+
+var NoAscii = false
+
+// Layout describes where the ascii art is placed relative to the fetch script output
+type Layout struct {
+	Position      string
+	Gap           int
+	VerticalAlign string
+	PaddingTop    int
+	PaddingLeft   int
+	AsciiSize     string
+}
+
+// Placement holds the position and size of the ascii art within a composed frame, in terminal cells
+type Placement struct {
+	Visible bool
+	Row     int
+	Column  int
+	Width   int
+	Height  int
+}
+
+// GetLayout returns the layout set by the config and command line flags
+func GetLayout() (Layout, error) {
+	layout := Layout{
+		Position:      config.AsciiPosition,
+		Gap:           max(config.AsciiGap, 0),
+		VerticalAlign: config.VerticalAlign,
+		PaddingTop:    max(config.PaddingTop, 0),
+		PaddingLeft:   max(config.PaddingLeft, 0),
+		AsciiSize:     config.AsciiSize,
+	}
+	if NoAscii {
+		layout.Position = "none"
+	}
+	if !slices.Contains(AsciiPositions, layout.Position) {
+		return layout, fmt.Errorf("invalid ascii position '%s', expected one of: %s", layout.Position, strings.Join(AsciiPositions, ", "))
+	}
+	if !slices.Contains(VerticalAlignments, layout.VerticalAlign) {
+		return layout, fmt.Errorf("invalid vertical alignment '%s', expected one of: %s", layout.VerticalAlign, strings.Join(VerticalAlignments, ", "))
+	}
+	if !slices.Contains(AsciiSizes, layout.AsciiSize) {
+		return layout, fmt.Errorf("invalid ascii size '%s', expected one of: %s", layout.AsciiSize, strings.Join(AsciiSizes, ", "))
+	}
+	return layout, nil
+}
+
+// PadRight appends spaces to a string until it takes the given amount of terminal cells
+func PadRight(str string, width int) string {
+	if padding := width - DisplayWidth(str); padding > 0 {
+		return str + strings.Repeat(" ", padding)
+	}
+	return str
+}
+
+// renderBlock splits text into lines that start with the graphic state the previous line ended with and end with a reset.
+// Lines are padded to the width of the widest one when pad is set
+func renderBlock(text string, pad bool) ([]string, int) {
+	lines := strings.Split(text, "\n")
+	// Measure in terminal cells so wide and combining characters line up
+	width := 0
+	for _, line := range lines {
+		width = max(width, DisplayWidth(line))
+	}
+	state := SGRState{}
+	for i, line := range lines {
+		rendered := line
+		if pad {
+			rendered = PadRight(line, width)
+		}
+		lines[i] = state.Sequence() + rendered + "\033[0m"
+		state.Scan(line)
+	}
+	return lines, width
+}
+
+// alignOffset returns the amount of lines a block has to be moved down to be aligned next to a taller one
+func alignOffset(height, otherHeight int, verticalAlign string) int {
+	if verticalAlign == "center" && height < otherHeight {
+		return (otherHeight - height) / 2
+	}
+	return 0
+}
+
+// ComposeFrame places the ascii art relative to the fetch script output according to the layout and returns where the art was placed
+func ComposeFrame(ascii, info string, layout Layout) (string, Placement) {
+	ascii = strings.TrimRight(ascii, "\n")
+	info = strings.TrimRight(info, "\n")
+	var lines []string
+	placement := Placement{Visible: layout.Position != "none", Row: layout.PaddingTop, Column: layout.PaddingLeft}
+	switch layout.Position {
+	case "none":
+		lines, _ = renderBlock(info, false)
+	case "top", "bottom":
+		asciiLines, asciiWidth := renderBlock(ascii, false)
+		infoLines, _ := renderBlock(info, false)
+		placement.Width, placement.Height = asciiWidth, len(asciiLines)
+		first, second := asciiLines, infoLines
+		if layout.Position == "bottom" {
+			first, second = infoLines, asciiLines
+		}
+		lines = append(lines, first...)
+		if layout.Gap > 0 {
+			lines = append(lines, "")
+		}
+		if layout.Position == "bottom" {
+			placement.Row += len(lines)
+		}
+		lines = append(lines, second...)
+	default:
+		asciiLines, asciiWidth := renderBlock(ascii, true)
+		infoLines, infoWidth := renderBlock(info, layout.Position == "right")
+		placement.Width, placement.Height = asciiWidth, len(asciiLines)
+		left, leftWidth, right := asciiLines, asciiWidth, infoLines
+		if layout.Position == "right" {
+			left, leftWidth, right = infoLines, infoWidth, asciiLines
+		}
+		height := max(len(left), len(right))
+		leftOffset := alignOffset(len(left), height, layout.VerticalAlign)
+		rightOffset := alignOffset(len(right), height, layout.VerticalAlign)
+		if layout.Position == "right" {
+			placement.Row += rightOffset
+			placement.Column += infoWidth + layout.Gap
+		} else {
+			placement.Row += leftOffset
+		}
+		for lineIndex := 0; lineIndex < height; lineIndex++ {
+			line := strings.Repeat(" ", leftWidth)
+			if i := lineIndex - leftOffset; i >= 0 && i < len(left) {
+				line = left[i]
+			}
+			line += strings.Repeat(" ", layout.Gap)
+			if i := lineIndex - rightOffset; i >= 0 && i < len(right) {
+				line += right[i]
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	final := strings.Repeat("\n", layout.PaddingTop)
+	for _, line := range lines {
+		final += strings.Repeat(" ", layout.PaddingLeft) + line + "\n"
+	}
+	return strings.TrimRight(final, "\n\t "), placement
+}
+
+func truncateLines(str string, width int) string {
+	lines := strings.Split(str, "\n")
+	for i, line := range lines {
+		lines[i] = TruncateString(line, width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// LayoutFrame composes the frame while adapting the layout to the size of the terminal
+func LayoutFrame(ascii, info string, layout Layout) (string, Placement) {
+	size, ok := GetTerminalSize()
+	if !ok || !config.ResponsiveLayout {
+		return ComposeFrame(ascii, info, layout)
+	}
+	asciiWidth := 0
+	for _, line := range strings.Split(ascii, "\n") {
+		asciiWidth = max(asciiWidth, DisplayWidth(line))
+	}
+	columns := size.Columns - layout.PaddingLeft
+	sideBySide := layout.Position == "left" || layout.Position == "right"
+	switch {
+	case layout.Position == "none":
+	case columns < config.HideAsciiBelow || asciiWidth > columns:
+		// Drop the art entirely
+		layout.Position = "none"
+	case sideBySide && columns < asciiWidth+layout.Gap+config.MinInfoWidth:
+		// Stack the art above the information
+		layout.Position = "top"
+	case sideBySide:
+		columns -= asciiWidth + layout.Gap
+	}
+	return ComposeFrame(ascii, truncateLines(info, columns), layout)
+}
+
+// FitAsciiArt picks the variant of the art to show with the auto size: the one whose height is the closest to the height of the
+// information when shown beside it, among the variants fitting in the terminal. The smallest variant is used if none fits
+func FitAsciiArt(variants []AsciiArtVariant, info string, layout Layout) string {
+	heightOf := func(ascii string) int {
+		if strings.HasPrefix(ascii, "#/") {
+			_, ascii, _ = strings.Cut(ascii, "\n")
+		}
+		return strings.Count(SplitAsciiFrames(ascii)[0], "\n") + 1
+	}
+	infoHeight := strings.Count(strings.TrimRight(info, "\n"), "\n") + 1
+	sideBySide := layout.Position == "left" || layout.Position == "right"
+
+	// Keep the prompt on screen after the output
+	maxHeight := -1
+	if size, ok := GetTerminalSize(); ok && size.Rows > 0 && config.ResponsiveLayout {
+		maxHeight = size.Rows - layout.PaddingTop - 1
+		if !sideBySide {
+			maxHeight -= infoHeight + 1
+		}
+	}
+	var fitting []AsciiArtVariant
+	for _, variant := range variants {
+		if maxHeight < 0 || heightOf(variant.Ascii) <= maxHeight {
+			fitting = append(fitting, variant)
+		}
+	}
+	if len(fitting) == 0 {
+		return variants[len(variants)-1].Ascii
+	}
+	if !sideBySide {
+		return fitting[0].Ascii
+	}
+	best := fitting[0]
+	for _, variant := range fitting[1:] {
+		if absInt(heightOf(variant.Ascii)-infoHeight) < absInt(heightOf(best.Ascii)-infoHeight) {
+			best = variant
+		}
+	}
+	return best.Ascii
+}
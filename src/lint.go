@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// AsciiDiagnostic describes a problem found in an ascii art file
+type AsciiDiagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+}
+
+func (diagnostic AsciiDiagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s [%s]", diagnostic.File, diagnostic.Line, diagnostic.Column, diagnostic.Severity, diagnostic.Message, diagnostic.Rule)
+}
+
+// placeholderRegex matches everything os.Expand would replace
+var placeholderRegex = regexp.MustCompile(`\$\{[^}]*\}?|\$[A-Za-z_][A-Za-z0-9_]*|\$[0-9*#$@!?-]`)
+
+// escapedSequenceRegex matches escape sequences written as text, which are printed literally
+var escapedSequenceRegex = regexp.MustCompile(`\\(033|e|x1[bB]|u001[bB])\[`)
+
+var knownPlaceholders = []string{"${C0}", "${C1}", "${C2}", "${C3}", "${C4}", "${C5}", "${C6}"}
+
+// LintAscii checks the content of an ascii art file, returning the problems found
+func LintAscii(file, content string, maxWidth, maxHeight int) []AsciiDiagnostic {
+	var diagnostics []AsciiDiagnostic
+	report := func(line, column int, severity, rule, message string) {
+		diagnostics = append(diagnostics, AsciiDiagnostic{File: file, Line: line, Column: column, Severity: severity, Rule: rule, Message: message})
+	}
+
+	content = strings.TrimRight(content, "\n")
+	lines := strings.Split(content, "\n")
+	firstLine := 1
+	if strings.HasPrefix(content, "#/") {
+		if _, _, err := ParseAsciiHeader(content); err != nil {
+			report(1, 1, "error", "header", fmt.Sprintf("invalid color header: %s", err))
+		}
+		lines = lines[1:]
+		firstLine = 2
+	} else {
+		report(1, 1, "warning", "header", "missing '#/' color header, the colors of the config will be used")
+	}
+	if height := strings.Count(SplitAsciiFrames(strings.Join(lines, "\n"))[0], "\n") + 1; height > maxHeight {
+		report(firstLine, 1, "warning", "height", fmt.Sprintf("art is %d lines high, more than the maximum of %d", height, maxHeight))
+	}
+
+	usesColors := false
+	widestLine, widestWidth := 0, 0
+	for i, line := range lines {
+		lineNumber := firstLine + i
+		for _, match := range placeholderRegex.FindAllStringIndex(line, -1) {
+			placeholder := line[match[0]:match[1]]
+			if !slices.Contains(knownPlaceholders, placeholder) {
+				report(lineNumber, match[0]+1, "error", "placeholder", fmt.Sprintf("unknown placeholder '%s', only ${C0} to ${C6} are supported", placeholder))
+			} else {
+				usesColors = true
+			}
+		}
+		if index := strings.IndexByte(line, '\033'); index != -1 {
+			report(lineNumber, index+1, "error", "raw-escape", "raw escape sequence, use the ${C0} to ${C6} placeholders instead")
+		}
+		if match := escapedSequenceRegex.FindStringIndex(line); match != nil {
+			report(lineNumber, match[0]+1, "warning", "escaped-sequence", fmt.Sprintf("'%s' is printed literally, use the ${C0} to ${C6} placeholders instead", line[match[0]:match[1]]))
+		}
+		if trimmed := strings.TrimRight(line, " \t"); trimmed != line {
+			report(lineNumber, len(trimmed)+1, "warning", "trailing-whitespace", "trailing whitespace")
+		}
+		if strings.TrimRight(line, " \t") == asciiFrameMarker {
+			// Frame markers are not printed
+			continue
+		}
+		if width := DisplayWidth(placeholderRegex.ReplaceAllString(line, "")); width > widestWidth {
+			widestLine, widestWidth = lineNumber, width
+		}
+	}
+	if widestWidth > maxWidth {
+		report(widestLine, 1, "warning", "width", fmt.Sprintf("art is %d cells wide, more than the maximum of %d", widestWidth, maxWidth))
+	}
+	if usesColors && !strings.HasSuffix(content, "${C0}") {
+		report(firstLine+len(lines)-1, len(lines[len(lines)-1])+1, "warning", "reset", "art does not end with ${C0}")
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Line != diagnostics[j].Line {
+			return diagnostics[i].Line < diagnostics[j].Line
+		}
+		return diagnostics[i].Column < diagnostics[j].Column
+	})
+	return diagnostics
+}
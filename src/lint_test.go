@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestLintAscii(t *testing.T) {
+	type result struct {
+		Line, Column int
+		Severity     string
+		Rule         string
+	}
+	tests := []struct {
+		name    string
+		content string
+		want    []result
+	}{
+		{"clean", "#/1;2\n${C1}ab${C2}cd${C0}\n", nil},
+		{"empty header", "#/\nab\n", nil},
+		{"missing header", "ab\n", []result{{1, 1, "warning", "header"}}},
+		{"invalid header", "#/1;zz\nab\n", []result{{1, 1, "error", "header"}}},
+		{"unknown placeholders", "#/1\n${C1}a${C7}b$HOME${C0}\n", []result{{2, 7, "error", "placeholder"}, {2, 13, "error", "placeholder"}}},
+		{"raw escape", "#/1\na\033[31mb\n", []result{{2, 2, "error", "raw-escape"}}},
+		{"escaped sequence", "#/1\n\\e[1m\n", []result{{2, 1, "warning", "escaped-sequence"}}},
+		{"trailing whitespace", "#/1\nab \t\ncd\n", []result{{2, 3, "warning", "trailing-whitespace"}}},
+		{"width", "#/1\nab\n${C1}abcdef${C0}\n", []result{{3, 1, "warning", "width"}}},
+		{"wide characters", "#/1\n日本語\n", []result{{2, 1, "warning", "width"}}},
+		{"height", "#/1\na\nb\nc\nd\n", []result{{2, 1, "warning", "height"}}},
+		{"frames", "#/1\na\nb\n#/frame\nc\nd\n", nil},
+		{"reset", "#/1\n${C1}ab\n", []result{{2, 8, "warning", "reset"}}},
+		{"sorted by position", "${C1}ab \n${C9}", []result{{1, 1, "warning", "header"}, {1, 8, "warning", "trailing-whitespace"}, {2, 1, "error", "placeholder"}, {2, 6, "warning", "reset"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []result
+			for _, diagnostic := range LintAscii("art", test.content, 5, 3) {
+				if diagnostic.File != "art" {
+					t.Errorf("diagnostic of file %q, want %q", diagnostic.File, "art")
+				}
+				got = append(got, result{diagnostic.Line, diagnostic.Column, diagnostic.Severity, diagnostic.Rule})
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("LintAscii(%q) = %v, want %v", test.content, got, test.want)
+			}
+		})
+	}
+}
+
+func TestBundledAsciiArts(t *testing.T) {
+	dir := "../config/ascii"
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) == 0 {
+		t.Fatalf("no ascii art found in %s", dir)
+	}
+	for _, entry := range entries {
+		bytes, err := os.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, diagnostic := range LintAscii(entry.Name(), string(bytes), validateMaxWidth, validateMaxHeight) {
+			t.Error(diagnostic)
+		}
+	}
+}
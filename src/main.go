@@ -5,10 +5,11 @@ import (
 	"fmt"
 	"gopkg.in/yaml.v3"
 	"log"
+	"maps"
 	"os"
 	"os/exec"
 	"path"
-	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -19,37 +20,157 @@ var systemConfigDir = "/etc/"
 var configPath = ""
 var fetchScriptPath = ""
 
+var configErr error
+
 var TimeTaken = false
+var Profile = ""
+var ShowVersion = false
+
+var config = DefaultConfig()
 
-var config = StormfetchConfig{
-	Ascii:            "auto",
-	FetchScript:      "auto",
-	AnsiiColors:      make([]int, 0),
-	ForceConfigAnsii: false,
-	ShowFSType:       false,
-	HiddenPartitions: make([]string, 0),
-	HiddenGPUS:       make([]int, 0),
+// DefaultConfig returns the built-in configuration, which the config file overrides
+func DefaultConfig() StormfetchConfig {
+	return StormfetchConfig{
+		Ascii:                  "auto",
+		AsciiAliases:           maps.Clone(DefaultAsciiAliases),
+		AsciiSize:              "auto",
+		AnimationDuration:      3000,
+		FetchScript:            "auto",
+		Layout:                 "default",
+		AnsiiColors:            make([]string, 0),
+		ForceConfigAnsii:       false,
+		ShowFSType:             false,
+		HiddenPartitions:       make([]string, 0),
+		HiddenGPUS:             make([]int, 0),
+		EnableHistory:          false,
+		HistoryFile:            "auto",
+		HistoryMaxEntries:      1000,
+		HistoryDeltaDays:       []int{7, 30},
+		HistorySparklineLength: 20,
+		Redact:                 false,
+		RedactRules:            RedactionRules,
+		RedactPatterns:         make([]string, 0),
+		RedactReplacement:      "[redacted]",
+		ResponsiveLayout:       true,
+		MinInfoWidth:           30,
+		HideAsciiBelow:         40,
+		AsciiPosition:          "left",
+		AsciiGap:               5,
+		VerticalAlign:          "top",
+		PaddingTop:             0,
+		PaddingLeft:            0,
+		ColorDepth:             "auto",
+		Color:                  "auto",
+		Theme:                  "",
+		ShowColorBlocks:        false,
+		ColorBlocksGlyph:       "█",
+		ColorBlocksWidth:       3,
+		ColorBlocksRows:        2,
+		LogoImage:              "",
+		ImageProtocol:          "auto",
+		LogoWidth:              30,
+		ExportFont:             "DejaVu Sans Mono, Menlo, Consolas, monospace",
+		ExportFontSize:         14,
+		ExportBackground:       "#1e1e1e",
+		ExportForeground:       "#d4d4d4",
+		ExportPalette:          make([]string, 0),
+		ExportPadding:          16,
+		ExportScale:            2,
+		ExportHTMLFragment:     false,
+	}
 }
 
 type StormfetchConfig struct {
-	Ascii             string   `yaml:"distro_ascii"`
-	DistroName        string   `yaml:"distro_name"`
-	FetchScript       string   `yaml:"fetch_script"`
-	AnsiiColors       []int    `yaml:"ansii_colors"`
-	ForceConfigAnsii  bool     `yaml:"force_config_ansii"`
-	ShowFSType        bool     `yaml:"show_fs_type"`
-	HiddenPartitions  []string `yaml:"hidden_partitions"`
-	HiddenFilesystems []string `yaml:"hidden_filesystems"`
-	HiddenGPUS        []int    `yaml:"hidden_gpus"`
+	Ascii                  string            `yaml:"distro_ascii"`
+	AsciiAliases           map[string]string `yaml:"ascii_aliases"`
+	AsciiSize              string            `yaml:"ascii_size"`
+	AnimationDuration      int               `yaml:"animation_duration"`
+	DistroName             string            `yaml:"distro_name"`
+	FetchScript            string            `yaml:"fetch_script"`
+	Layout                 string            `yaml:"layout"`
+	AnsiiColors            []string          `yaml:"ansii_colors"`
+	ForceConfigAnsii       bool              `yaml:"force_config_ansii"`
+	ShowFSType             bool              `yaml:"show_fs_type"`
+	HiddenPartitions       []string          `yaml:"hidden_partitions"`
+	HiddenFilesystems      []string          `yaml:"hidden_filesystems"`
+	HiddenGPUS             []int             `yaml:"hidden_gpus"`
+	EnableHistory          bool              `yaml:"enable_history"`
+	HistoryFile            string            `yaml:"history_file"`
+	HistoryMaxEntries      int               `yaml:"history_max_entries"`
+	HistoryDeltaDays       []int             `yaml:"history_delta_days"`
+	HistorySparklineLength int               `yaml:"history_sparkline_length"`
+	Redact                 bool              `yaml:"redact"`
+	RedactRules            []string          `yaml:"redact_rules"`
+	RedactPatterns         []string          `yaml:"redact_patterns"`
+	RedactReplacement      string            `yaml:"redact_replacement"`
+	ResponsiveLayout       bool              `yaml:"responsive_layout"`
+	MinInfoWidth           int               `yaml:"min_info_width"`
+	HideAsciiBelow         int               `yaml:"hide_ascii_below"`
+	AsciiPosition          string            `yaml:"ascii_position"`
+	AsciiGap               int               `yaml:"ascii_gap"`
+	VerticalAlign          string            `yaml:"vertical_align"`
+	PaddingTop             int               `yaml:"padding_top"`
+	PaddingLeft            int               `yaml:"padding_left"`
+	ColorDepth             string            `yaml:"color_depth"`
+	Color                  string            `yaml:"color"`
+	Theme                  string            `yaml:"theme"`
+	ShowColorBlocks        bool              `yaml:"show_color_blocks"`
+	ColorBlocksGlyph       string            `yaml:"color_blocks_glyph"`
+	ColorBlocksWidth       int               `yaml:"color_blocks_width"`
+	ColorBlocksRows        int               `yaml:"color_blocks_rows"`
+	LogoImage              string            `yaml:"logo_image"`
+	ImageProtocol          string            `yaml:"image_protocol"`
+	LogoWidth              int               `yaml:"logo_width"`
+	ExportFont             string            `yaml:"export_font"`
+	ExportFontSize         int               `yaml:"export_font_size"`
+	ExportBackground       string            `yaml:"export_background"`
+	ExportForeground       string            `yaml:"export_foreground"`
+	ExportPalette          []string          `yaml:"export_palette"`
+	ExportPadding          int               `yaml:"export_padding"`
+	ExportScale            int               `yaml:"export_scale"`
+	ExportHTMLFragment     bool              `yaml:"export_html_fragment"`
 }
 
 func main() {
-	readConfig()
+	configErr = readConfig()
 	readFlags()
-	runStormfetch()
+	if Profile != "" {
+		if err := applyProfile(Profile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+		// Options given on the command line take precedence over the profile
+		flag.Parse()
+	}
+	if err := resolveLayout(); err != nil && configErr == nil {
+		configErr = err
+	}
+	if ShowVersion {
+		printVersion()
+		return
+	}
+	args := flag.Args()
+	if len(args) != 0 && (OutputFormat != "terminal" || TextFormat != "terminal") {
+		fmt.Fprintln(os.Stderr, "Error: --output and --format cannot be combined with a command")
+		os.Exit(2)
+	}
+	if len(args) == 0 {
+		if configErr != nil {
+			log.Fatal(configErr)
+		}
+		if OutputFormat != "terminal" {
+			os.Exit(runExport())
+		}
+		if TextFormat != "terminal" {
+			os.Exit(runTextFormat())
+		}
+		runStormfetch()
+		return
+	}
+	runCommand(Commands, nil, args)
 }
 
-func readConfig() {
+func readConfig() error {
 	// Get home directory
 	userConfigDir, _ := os.UserConfigDir()
 	// Find valid config directory
@@ -58,25 +179,25 @@ func readConfig() {
 	} else if _, err := os.Stat(path.Join(systemConfigDir, "stormfetch/config.yaml")); err == nil {
 		configPath = path.Join(systemConfigDir, "stormfetch/config.yaml")
 	} else {
-		log.Fatalf("Config file not found: %s", err.Error())
+		return fmt.Errorf("Config file not found: %s", err.Error())
 	}
 	// Parse config
 	bytes, err := os.ReadFile(configPath)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	err = yaml.Unmarshal(bytes, &config)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	if config.FetchScript == "" {
-		log.Fatalf("Fetch script path is empty")
+		return fmt.Errorf("Fetch script path is empty")
 	} else if config.FetchScript != "auto" {
 		stat, err := os.Stat(config.FetchScript)
 		if err != nil {
-			log.Fatalf("Fetch script file not found: %s", err.Error())
+			return fmt.Errorf("Fetch script file not found: %s", err.Error())
 		} else if stat.IsDir() {
-			log.Fatalf("Fetch script path points to a directory")
+			return fmt.Errorf("Fetch script path points to a directory")
 		}
 	}
 	if _, err := os.Stat(path.Join(userConfigDir, "stormfetch/fetch_script.sh")); err == nil {
@@ -84,38 +205,108 @@ func readConfig() {
 	} else if _, err := os.Stat(path.Join(systemConfigDir, "stormfetch/fetch_script.sh")); err == nil {
 		fetchScriptPath = path.Join(systemConfigDir, "stormfetch/fetch_script.sh")
 	} else {
-		log.Fatalf("Fetch script file not found: %s", err.Error())
+		return fmt.Errorf("Fetch script file not found: %s", err.Error())
+	}
+	return nil
+}
+
+// applyProfile reads a partial config file of the profiles directory over the config
+func applyProfile(name string) error {
+	profilePath := FindConfigFile("profiles", name+".yaml")
+	if profilePath == "" {
+		return fmt.Errorf("profile '%s' not found", name)
+	}
+	bytes, err := os.ReadFile(profilePath)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(bytes, &config); err != nil {
+		return fmt.Errorf("could not parse profile %s: %s", profilePath, err)
+	}
+	return nil
+}
+
+// resolveLayout replaces the fetch script with the script of the layouts directory set in the config
+func resolveLayout() error {
+	if config.Layout == "" || config.Layout == "default" {
+		return nil
 	}
+	layoutPath := FindConfigFile("layouts", config.Layout+".sh")
+	if layoutPath == "" {
+		return fmt.Errorf("Layout '%s' not found", config.Layout)
+	}
+	fetchScriptPath = layoutPath
+	return nil
+}
+
+// addGlobalFlags defines the flags accepted by stormfetch and all of its commands
+func addGlobalFlags(flags *flag.FlagSet) {
+	flags.StringVar(&config.Ascii, "ascii", config.Ascii, "Set distro ascii")
+	flags.StringVar(&config.AsciiSize, "ascii-size", config.AsciiSize, "Set ascii art size ("+strings.Join(AsciiSizes, ", ")+")")
+	flags.IntVar(&config.AnimationDuration, "animation-duration", config.AnimationDuration, "Set for how many milliseconds animated ascii arts are played, 0 to only show their last frame")
+	flags.StringVar(&config.DistroName, "distro-name", config.DistroName, "Set distro name")
+	flags.BoolVar(&TimeTaken, "time-taken", TimeTaken, "Show time taken for fetched information")
+	flags.StringVar(&config.Color, "color", config.Color, "Set when to use colors ("+strings.Join(ColorModes, ", ")+")")
+	flags.StringVar(&config.LogoImage, "logo-image", config.LogoImage, "Show a PNG or JPEG image in place of the ascii art when the terminal supports it")
+	flags.StringVar(&config.ImageProtocol, "image-protocol", config.ImageProtocol, "Set image protocol ("+strings.Join(ImageProtocols, ", ")+")")
+	flags.StringVar(&config.Theme, "theme", config.Theme, "Set color theme")
+	flags.BoolVar(&config.Redact, "redact", config.Redact, "Mask sensitive information such as hostname, IP address and partition names")
+	flags.StringVar(&config.AsciiPosition, "ascii-position", config.AsciiPosition, "Set ascii art position ("+strings.Join(AsciiPositions, ", ")+")")
+	flags.BoolVar(&NoAscii, "no-ascii", NoAscii, "Only show the fetched information")
+	flags.IntVar(&config.AsciiGap, "ascii-gap", config.AsciiGap, "Set the gap between the ascii art and the information")
+	flags.StringVar(&config.VerticalAlign, "vertical-align", config.VerticalAlign, "Set the vertical alignment of the shorter column ("+strings.Join(VerticalAlignments, ", ")+")")
+	flags.IntVar(&config.PaddingTop, "padding-top", config.PaddingTop, "Set the amount of empty lines printed above the output")
+	flags.IntVar(&config.PaddingLeft, "padding-left", config.PaddingLeft, "Set the amount of spaces printed left of the output")
+}
+
+// addRootFlags defines the flags accepted by stormfetch when run without a command
+func addRootFlags(flags *flag.FlagSet) {
+	addGlobalFlags(flags)
+	flags.BoolVar(&ShowVersion, "version", false, "Show version information")
+	flags.StringVar(&Profile, "profile", Profile, "Read the given profile of the profiles config directory over the config")
+	flags.StringVar(&config.Layout, "layout", config.Layout, "Print the information using the given fetch script of the layouts config directory, or default")
+	// Only defined for stormfetch itself as commands print their own output, and ascii import has its own format flag
+	flags.StringVar(&TextFormat, "format", TextFormat, "Print the output as plain text for pasting ("+strings.Join(TextFormats, ", ")+")")
+	flags.StringVar(&OutputFormat, "output", OutputFormat, "Write the output to the file given to --output-file, or stdout, in the given format ("+strings.Join(OutputFormats, ", ")+")")
+	flags.StringVar(&OutputFile, "output-file", OutputFile, "Write the output of --output to the given file instead of stdout")
+	flags.BoolVar(&config.ExportHTMLFragment, "html-fragment", config.ExportHTMLFragment, "Only write the block of the output with --output html, to embed it into another page")
 }
 
 func readFlags() {
-	flag.StringVar(&config.Ascii, "ascii", config.Ascii, "Set distro ascii")
-	flag.StringVar(&config.DistroName, "distro-name", config.DistroName, "Set distro name")
-	flag.BoolVar(&TimeTaken, "time-taken", false, "Show time taken for fetched information")
+	addRootFlags(flag.CommandLine)
+	flag.Usage = func() {
+		printUsage(flag.CommandLine, nil, Commands)
+	}
 	flag.Parse()
 }
 
-func SetupFetchEnv(showTimeTaken bool) []string {
+func SetupFetchEnv(timeTaken func(key string, milliseconds int64), redactor *Redactor) map[string]string {
 	var env = make(map[string]string)
 	setVariable := func(key string, setter func() string) {
 		start := time.Now().UnixMilli()
 		env[key] = setter()
 		end := time.Now().UnixMilli()
-		if showTimeTaken {
-			fmt.Println(fmt.Sprintf("Setting '%s' took %d milliseconds", key, end-start))
+		if timeTaken != nil {
+			timeTaken(key, end-start)
 		}
 	}
-	setVariable("PACKAGES", func() string { return GetInstalledPackages() })
+	start := time.Now().UnixMilli()
+	packages := GetPackageCounts()
+	end := time.Now().UnixMilli()
+	if timeTaken != nil {
+		timeTaken("PACKAGES", end-start)
+	}
+	env["PACKAGES"] = FormatPackageCounts(packages)
 	setVariable("DISTRO_LONG_NAME", func() string { return GetDistroInfo().LongName })
 	setVariable("DISTRO_SHORT_NAME", func() string { return GetDistroInfo().ShortName })
 	setVariable("CPU_MODEL", func() string { return GetCPUModel() })
 	setVariable("MOTHERBOARD", func() string { return GetMotherboardModel() })
 	setVariable("CPU_THREADS", func() string { return strconv.Itoa(GetCPUThreads()) })
-	start := time.Now().UnixMilli()
+	start = time.Now().UnixMilli()
 	memory := GetMemoryInfo()
-	end := time.Now().UnixMilli()
-	if showTimeTaken {
-		fmt.Println(fmt.Sprintf("Setting '%s' took %d milliseconds", "MEM_*", end-start))
+	end = time.Now().UnixMilli()
+	if timeTaken != nil {
+		timeTaken("MEM_*", end-start)
 	}
 	if memory != nil {
 		env["MEM_TOTAL"] = strconv.Itoa(memory.MemTotal)
@@ -125,8 +316,8 @@ func SetupFetchEnv(showTimeTaken bool) []string {
 	start = time.Now().UnixMilli()
 	partitions := GetMountedPartitions(config.HiddenPartitions, config.HiddenFilesystems)
 	end = time.Now().UnixMilli()
-	if showTimeTaken {
-		fmt.Println(fmt.Sprintf("Setting '%s' took %d milliseconds", "PARTITION_*", end-start))
+	if timeTaken != nil {
+		timeTaken("PARTITION_*", end-start)
 	}
 	if len(partitions) != 0 {
 		env["MOUNTED_PARTITIONS"] = strconv.Itoa(len(partitions))
@@ -153,8 +344,8 @@ func SetupFetchEnv(showTimeTaken bool) []string {
 	start = time.Now().UnixMilli()
 	monitors := GetMonitorResolution()
 	end = time.Now().UnixMilli()
-	if showTimeTaken {
-		fmt.Println(fmt.Sprintf("Setting '%s' took %d milliseconds", "MONITOR_*", end-start))
+	if timeTaken != nil {
+		timeTaken("MONITOR_*", end-start)
 	}
 	if len(monitors) != 0 {
 		env["CONNECTED_MONITORS"] = strconv.Itoa(len(monitors))
@@ -165,8 +356,8 @@ func SetupFetchEnv(showTimeTaken bool) []string {
 	start = time.Now().UnixMilli()
 	gpus := GetGPUModels()
 	end = time.Now().UnixMilli()
-	if showTimeTaken {
-		fmt.Println(fmt.Sprintf("Setting '%s' took %d milliseconds", "GPU_*", end-start))
+	if timeTaken != nil {
+		timeTaken("GPU_*", end-start)
 	}
 	if len(gpus) != 0 {
 		env["CONNECTED_GPUS"] = strconv.Itoa(len(gpus))
@@ -177,111 +368,249 @@ func SetupFetchEnv(showTimeTaken bool) []string {
 			env["GPU"+strconv.Itoa(i+1)] = gpu
 		}
 	}
+	if config.EnableHistory {
+		start = time.Now().UnixMilli()
+		history, err := RecordHistory(memory, partitions, packages)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not record history: %s\n", err)
+		}
+		SetHistoryVariables(env, history, partitions)
+		end = time.Now().UnixMilli()
+		if timeTaken != nil {
+			timeTaken("*_DELTA_*/*_SPARKLINE", end-start)
+		}
+	}
 
-	var ret = make([]string, len(env))
-	i := 0
-	for key, value := range env {
-		ret[i] = fmt.Sprintf("%s=%s", key, value)
-		i++
+	if redactor != nil {
+		redactor.RedactEnv(env)
 	}
-	return ret
+
+	return env
 }
 
-func runStormfetch() {
-	// Fetch ascii art and apply colors
+// GetColorMap returns the color variables used by the ascii art and the fetch script
+func GetColorMap() (map[string]string, error) {
+	if !slices.Contains(ColorModes, config.Color) {
+		return nil, fmt.Errorf("invalid color mode '%s', expected one of: %s", config.Color, strings.Join(ColorModes, ", "))
+	}
+	if !slices.Contains(ColorDepths, config.ColorDepth) {
+		return nil, fmt.Errorf("invalid color depth '%s', expected one of: %s", config.ColorDepth, strings.Join(ColorDepths, ", "))
+	}
+	theme, err := GetTheme()
+	if err != nil {
+		return nil, err
+	}
+	colors, roles := config.AnsiiColors, defaultRoles
+	if theme != nil {
+		// Themes take precedence over the config and the colors of the ascii art header
+		colors, roles = theme.Colors, theme.Roles()
+	}
+
 	colorMap := make(map[string]string)
+	if !ColorEnabled() {
+		// Leave every variable empty so the art and the fetch script produce plain text
+		for i := 0; i <= 6; i++ {
+			colorMap["C"+strconv.Itoa(i)] = ""
+		}
+		for role := range roles {
+			colorMap[role] = ""
+		}
+		colorMap["COLOR_BLOCKS"] = ""
+		return colorMap, nil
+	}
+	depth := GetColorDepth()
 	colorMap["C0"] = "\033[0m"
-	setColorMap := func() {
-		for i := 0; i < 6; i++ {
-			if i > len(config.AnsiiColors)-1 {
-				colorMap["C"+strconv.Itoa(i+1)] = "\033[0m"
-				continue
-			}
-			colorMap["C"+strconv.Itoa(i+1)] = fmt.Sprintf("\033[1m\033[38;5;%dm", config.AnsiiColors[i])
+	for i := 0; i < 6; i++ {
+		if i > len(colors)-1 {
+			colorMap["C"+strconv.Itoa(i+1)] = "\033[0m"
+			continue
+		}
+		state, err := ParseColorSpec(colors[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid color C%d '%s': %s", i+1, colors[i], err)
+		}
+		colorMap["C"+strconv.Itoa(i+1)] = state.Downgrade(depth).Sequence()
+	}
+	// Roles either refer to a color slot or hold a color of their own
+	for role, spec := range roles {
+		if sequence, ok := colorMap[spec]; ok {
+			colorMap[role] = sequence
+			continue
+		}
+		state, err := ParseColorSpec(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s color '%s': %s", role, spec, err)
 		}
+		colorMap[role] = state.Downgrade(depth).Sequence()
+	}
+	colorMap["COLOR_BLOCKS"] = GetColorBlocks()
+	return colorMap, nil
+}
+
+// PrepareAscii applies the colors of the ascii art header to the config and expands the color variables of the art.
+// An invalid header is reported on stderr and ignored
+func PrepareAscii(ascii string) (string, map[string]string, error) {
+	headerColors, ascii, err := ParseAsciiHeader(ascii)
+	if err != nil {
+		// Keep going with the colors of the config rather than failing over a broken art file
+		fmt.Fprintf(os.Stderr, "Warning: ignoring invalid ascii art color header: %s\n", err)
+		_, ascii, _ = strings.Cut(ascii, "\n")
 	}
-	setColorMap()
-	ascii := GetDistroAsciiArt()
-	if strings.HasPrefix(ascii, "#/") {
-		firstLine := strings.Split(ascii, "\n")[0]
-		if !config.ForceConfigAnsii {
-			ansiiColors := strings.Split(strings.TrimPrefix(firstLine, "#/"), ";")
-			for i, color := range ansiiColors {
-				atoi, err := strconv.Atoi(color)
-				if err != nil {
-					log.Fatal(err)
-				}
-				if i < len(config.AnsiiColors) {
-					config.AnsiiColors[i] = atoi
-				} else {
-					config.AnsiiColors = append(config.AnsiiColors, atoi)
-				}
+	if headerColors != nil && !config.ForceConfigAnsii {
+		for i, color := range headerColors {
+			if i < len(config.AnsiiColors) {
+				config.AnsiiColors[i] = color
+			} else {
+				config.AnsiiColors = append(config.AnsiiColors, color)
 			}
-			setColorMap()
 		}
-		ascii = os.Expand(ascii, func(s string) string {
-			return colorMap[s]
-		})
-		ascii = strings.TrimPrefix(ascii, firstLine+"\n")
-	} else {
-		ascii = os.Expand(ascii, func(s string) string {
-			return colorMap[s]
-		})
 	}
-	asciiSplit := strings.Split(ascii, "\n")
-	asciiNoColor := StripAnsii(ascii)
-	//Execute fetch script
+	colorMap, err := GetColorMap()
+	if err != nil {
+		return "", nil, err
+	}
+	return expandAsciiColors(ascii, colorMap), colorMap, nil
+}
+
+// expandAsciiColors replaces the color variables of an art without its header with the given colors
+func expandAsciiColors(ascii string, colorMap map[string]string) string {
+	return os.Expand(ascii, func(s string) string {
+		return colorMap[s]
+	})
+}
+
+// stripAsciiHeader returns an art without its '#/' header, also removing invalid headers
+func stripAsciiHeader(ascii string) string {
+	if _, body, err := ParseAsciiHeader(ascii); err == nil {
+		return body
+	}
+	_, body, _ := strings.Cut(ascii, "\n")
+	return body
+}
+
+// RunFetchScript executes the fetch script with the fetched information and colors set as environment variables
+func RunFetchScript(colorMap map[string]string, timeTaken func(key string, milliseconds int64), redactor *Redactor) (string, error) {
 	cmd := exec.Command("/bin/bash", fetchScriptPath)
 	cmd.Dir = path.Dir(fetchScriptPath)
 	cmd.Env = os.Environ()
-	cmd.Env = append(cmd.Env, SetupFetchEnv(TimeTaken)...)
-	cmd.Env = append(cmd.Env, "C0=\033[0m")
+	for key, value := range SetupFetchEnv(timeTaken, redactor) {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
+	}
 	for key, value := range colorMap {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
 	}
 	out, err := cmd.Output()
 	if err != nil {
-		log.Fatalf("Error: Could not run fetch script: %s", err)
+		return "", fmt.Errorf("Could not run fetch script: %s", err)
+	}
+	if redactor != nil {
+		return redactor.RedactString(string(out)), nil
+	}
+	return string(out), nil
+}
+
+// RenderStormfetch returns the ascii art and fetch script output merged together, showing the last frame of animated arts
+func RenderStormfetch() (string, error) {
+	frames, _, err := RenderStormfetchFrames()
+	if err != nil {
+		return "", err
 	}
-	// Print Distro Information
-	maxWidth := 0
-	for _, line := range strings.Split(asciiNoColor, "\n") {
-		if len(line) > maxWidth {
-			maxWidth = len(line)
+	return frames[len(frames)-1], nil
+}
+
+// RenderedOutput holds the ascii art with its colors applied and the output of the fetch script, before they are merged
+type RenderedOutput struct {
+	Layout Layout
+	Ascii  string
+	Info   string
+	Delay  time.Duration
+	Logo   *LogoImage
+}
+
+// RenderStormfetchOutput fetches the ascii art and runs the fetch script
+func RenderStormfetchOutput() (*RenderedOutput, error) {
+	layout, err := GetLayout()
+	if err != nil {
+		return nil, err
+	}
+	// Fetch ascii art and apply colors
+	variants := GetDistroAsciiArts()
+	selected := SelectAsciiArt(variants, layout.AsciiSize)
+	ascii, colorMap, err := PrepareAscii(selected)
+	if err != nil {
+		return nil, err
+	}
+	// Reserve the cells of the image logo in place of the art, keeping the colors of the art for the fetch script
+	logo, err := GetLogoImage()
+	if err != nil {
+		return nil, err
+	}
+	if logo != nil {
+		ascii = logo.Placeholder()
+	}
+	var redactor *Redactor
+	if config.Redact {
+		redactor, err = NewRedactor()
+		if err != nil {
+			return nil, fmt.Errorf("Could not setup redaction: %s", err)
 		}
 	}
-	final := ""
-	y := len(asciiSplit)
-	if len(asciiSplit) < len(strings.Split(string(out), "\n")) {
-		y = len(strings.Split(string(out), "\n"))
+	//Execute fetch script
+	var timeTaken func(key string, milliseconds int64)
+	if TimeTaken {
+		// Timings go to stderr so they are not mixed into exported files
+		timeTaken = func(key string, milliseconds int64) {
+			fmt.Fprintf(os.Stderr, "Setting '%s' took %d milliseconds\n", key, milliseconds)
+		}
+	}
+	out, err := RunFetchScript(colorMap, timeTaken, redactor)
+	if err != nil {
+		return nil, err
 	}
-	for lineIndex := 0; lineIndex < y; lineIndex++ {
-		line := ""
-		for i := 0; i < maxWidth+5; i++ {
-			line = line + " "
+	// Pick the size of the art once the height of the information is known
+	if layout.AsciiSize == "auto" && logo == nil && layout.Position != "none" && len(variants) > 1 {
+		if fitted := FitAsciiArt(variants, out, layout); fitted != selected {
+			// The colors of the first art were already applied and passed to the fetch script, reuse them
+			selected = fitted
+			ascii = expandAsciiColors(stripAsciiHeader(selected), colorMap)
 		}
-		lastAsciiColor := ""
-		if lineIndex < len(asciiSplit) {
-			line = asciiSplit[lineIndex]
-			lineVisibleLength := len(strings.Split(asciiNoColor, "\n")[lineIndex])
-			if lineIndex != 0 {
-				r := regexp.MustCompile("\033[38;5;[0-9]+m")
-				matches := r.FindAllString(asciiSplit[lineIndex-1], -1)
-				if len(matches) != 0 {
-					lastAsciiColor = r.FindAllString(asciiSplit[lineIndex-1], -1)[len(matches)-1]
-				}
-			}
-			for i := lineVisibleLength; i < maxWidth+5; i++ {
-				line = line + " "
-			}
-			asciiSplit[lineIndex] = lastAsciiColor + line
+	}
+	return &RenderedOutput{Layout: layout, Ascii: ascii, Info: out, Delay: GetAsciiFrameDelay(selected), Logo: logo}, nil
+}
+
+// RenderStormfetchFrames returns the output merged with each frame of the ascii art, along with the delay between frames
+func RenderStormfetchFrames() ([]string, time.Duration, error) {
+	output, err := RenderStormfetchOutput()
+	if err != nil {
+		return nil, 0, err
+	}
+	var frames []string
+	for _, asciiFrame := range SplitAsciiFrames(output.Ascii) {
+		frame, placement := LayoutFrame(asciiFrame, output.Info, output.Layout)
+		if !ColorEnabled() {
+			frames = append(frames, StripAnsii(frame))
+			continue
 		}
-		str := string(out)
-		if lineIndex < len(strings.Split(str, "\n")) {
-			line = line + colorMap["C0"] + strings.Split(str, "\n")[lineIndex]
+		frame += "\033[0m"
+		if output.Logo != nil && placement.Visible {
+			frame = output.Logo.DrawImage(frame, placement)
 		}
-		final += lastAsciiColor + line + "\n"
+		frames = append(frames, frame)
+	}
+	return frames, output.Delay, nil
+}
+
+func runStormfetch() {
+	frames, delay, err := RenderStormfetchFrames()
+	if err != nil {
+		log.Fatalf("Error: %s", err)
+	}
+	final := frames[len(frames)-1]
+	// Frames can only be redrawn in place when the whole output fits in the terminal
+	size, ok := GetTerminalSize()
+	if len(frames) > 1 && AnimationEnabled() && ok && strings.Count(final, "\n") < size.Rows {
+		PlayAnimation(frames, delay)
+		return
 	}
-	final = strings.TrimRight(final, "\n\t ")
-	fmt.Println(final + "\033[0m")
+	fmt.Println(final)
 }
@@ -37,15 +37,28 @@ func (pm *PackageManager) CountPackages() int {
 	return strings.Count(string(output), "\n")
 }
 
-func GetInstalledPackages() (ret string) {
+type PackageCount struct {
+	Manager string
+	Count   int
+}
+
+func GetPackageCounts() (ret []PackageCount) {
 	for _, pm := range PackageManagers {
 		count := pm.CountPackages()
 		if count > 0 {
-			if ret == "" {
-				ret += fmt.Sprintf("%d (%s)", count, pm.Name)
-			} else {
-				ret += fmt.Sprintf(" %d (%s)", count, pm.Name)
-			}
+			ret = append(ret, PackageCount{Manager: pm.Name, Count: count})
+		}
+	}
+
+	return ret
+}
+
+func FormatPackageCounts(counts []PackageCount) (ret string) {
+	for _, count := range counts {
+		if ret == "" {
+			ret += fmt.Sprintf("%d (%s)", count.Count, count.Manager)
+		} else {
+			ret += fmt.Sprintf(" %d (%s)", count.Count, count.Manager)
 		}
 	}
 
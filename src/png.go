@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// Size of the glyphs of the bitmap font in pixels
+const fontWidth, fontHeight = 8, 16
+
+// boxArms holds the weight of the up, right, down and left arms of box drawing characters, 1 for light and 2 for heavy lines
+var boxArms = map[rune][4]int{
+	'─': {0, 1, 0, 1}, '━': {0, 2, 0, 2}, '│': {1, 0, 1, 0}, '┃': {2, 0, 2, 0},
+	'┌': {0, 1, 1, 0}, '┐': {0, 0, 1, 1}, '└': {1, 1, 0, 0}, '┘': {1, 0, 0, 1},
+	'┏': {0, 2, 2, 0}, '┓': {0, 0, 2, 2}, '┗': {2, 2, 0, 0}, '┛': {2, 0, 0, 2},
+	'├': {1, 1, 1, 0}, '┤': {1, 0, 1, 1}, '┬': {0, 1, 1, 1}, '┴': {1, 1, 0, 1}, '┼': {1, 1, 1, 1},
+	'┣': {2, 2, 2, 0}, '┫': {2, 0, 2, 2}, '┳': {0, 2, 2, 2}, '┻': {2, 2, 0, 2}, '╋': {2, 2, 2, 2},
+	'╭': {0, 1, 1, 0}, '╮': {0, 0, 1, 1}, '╯': {1, 0, 0, 1}, '╰': {1, 1, 0, 0},
+	'╴': {0, 0, 0, 1}, '╵': {1, 0, 0, 0}, '╶': {0, 1, 0, 0}, '╷': {0, 0, 1, 0},
+	// Double lines are drawn as heavy ones
+	'═': {0, 2, 0, 2}, '║': {2, 0, 2, 0}, '╔': {0, 2, 2, 0}, '╗': {0, 0, 2, 2}, '╚': {2, 2, 0, 0}, '╝': {2, 0, 0, 2},
+	'╠': {2, 2, 2, 0}, '╣': {2, 0, 2, 2}, '╦': {0, 2, 2, 2}, '╩': {2, 2, 0, 2}, '╬': {2, 2, 2, 2},
+}
+
+// quadrants holds the upper left, upper right, lower left and lower right quadrants filled by the quadrant block elements
+var quadrants = map[rune][4]bool{
+	'▖': {false, false, true, false}, '▗': {false, false, false, true}, '▘': {true, false, false, false},
+	'▙': {true, false, true, true}, '▚': {true, false, false, true}, '▛': {true, true, true, false},
+	'▜': {true, true, false, true}, '▝': {false, true, false, false}, '▞': {false, true, true, false},
+	'▟': {false, true, true, true},
+}
+
+// pngGlyphMask returns the coverage of each pixel of a glyph the given size in pixels, from 0 to 1 and indexed by [y][x].
+// Block elements, box drawing and braille characters are drawn to fill their cells, other characters are scaled from the bitmap font
+func pngGlyphMask(r rune, width, height, scale int) [][]float64 {
+	mask := make([][]float64, height)
+	for y := range mask {
+		mask[y] = make([]float64, width)
+	}
+	fill := func(x0, y0, x1, y1 int, coverage float64) {
+		for y := max(y0, 0); y < min(y1, height); y++ {
+			for x := max(x0, 0); x < min(x1, width); x++ {
+				mask[y][x] = coverage
+			}
+		}
+	}
+
+	switch {
+	case r == '▀':
+		fill(0, 0, width, height/2, 1)
+	case r >= '▁' && r <= '█':
+		// Lower eighths
+		fill(0, height-height*int(r-'▀')/8, width, height, 1)
+	case r >= '▉' && r <= '▏':
+		// Left eighths
+		fill(0, 0, width*int('▐'-r)/8, height, 1)
+	case r == '▐':
+		fill(width/2, 0, width, height, 1)
+	case r >= '░' && r <= '▓':
+		fill(0, 0, width, height, float64(r-'░'+1)/4)
+	case r == '▔':
+		fill(0, 0, width, height/8, 1)
+	case r == '▕':
+		fill(width-width/8, 0, width, height, 1)
+	case quadrants[r] != [4]bool{}:
+		for i, filled := range quadrants[r] {
+			if filled {
+				x, y := i%2*width/2, i/2*height/2
+				fill(x, y, x+width/2+i%2*(width%2), y+height/2+i/2*(height%2), 1)
+			}
+		}
+	case boxArms[r] != [4]int{}:
+		arms := boxArms[r]
+		centerX, centerY := width/2, height/2
+		for i, weight := range arms {
+			thickness := weight * scale
+			if weight == 0 {
+				continue
+			}
+			switch i {
+			case 0:
+				fill(centerX-thickness/2, 0, centerX-thickness/2+thickness, centerY+thickness/2+thickness%2, 1)
+			case 1:
+				fill(centerX-thickness/2, centerY-thickness/2, width, centerY-thickness/2+thickness, 1)
+			case 2:
+				fill(centerX-thickness/2, centerY-thickness/2, centerX-thickness/2+thickness, height, 1)
+			case 3:
+				fill(0, centerY-thickness/2, centerX+thickness/2+thickness%2, centerY-thickness/2+thickness, 1)
+			}
+		}
+	case r >= 0x2800 && r <= 0x28ff:
+		bits := int(r - 0x2800)
+		size := max(width/4, 1)
+		for dy := 0; dy < 4; dy++ {
+			for dx := 0; dx < 2; dx++ {
+				if bits&brailleDots[dy][dx] != 0 {
+					x, y := width*(2*dx+1)/4-size/2, height*(2*dy+1)/8-size/2
+					fill(x, y, x+size, y+size, 1)
+				}
+			}
+		}
+	default:
+		glyph, ok := fontGlyphs[r]
+		if !ok {
+			// Draw the outline of a box for characters missing from the font
+			fill(scale, 2*scale, width-scale, height-2*scale, 1)
+			fill(2*scale, 3*scale, width-2*scale, height-3*scale, 0)
+			break
+		}
+		for y := 0; y < height; y++ {
+			row := glyph[y*fontHeight/height]
+			for x := 0; x < width; x++ {
+				mask[y][x] = float64(row>>(2*(fontWidth-1-x*fontWidth/width))&3) / 3
+			}
+		}
+	}
+	return mask
+}
+
+// blendPixel draws a color over a pixel of the image with the given opacity
+func blendPixel(img *image.RGBA, x, y int, rgb [3]uint8, alpha float64) {
+	if alpha <= 0 || !(image.Point{X: x, Y: y}).In(img.Rect) {
+		return
+	}
+	current := img.RGBAAt(x, y)
+	blend := func(from, to uint8) uint8 {
+		return uint8(float64(from)*(1-alpha) + float64(to)*alpha + 0.5)
+	}
+	img.SetRGBA(x, y, color.RGBA{R: blend(current.R, rgb[0]), G: blend(current.G, rgb[1]), B: blend(current.B, rgb[2]), A: 0xff})
+}
+
+// EncodePNG draws styled lines using the embedded bitmap font, scaled by export_scale, on the background color
+func EncodePNG(lines [][]StyledRun, palette ExportPalette) ([]byte, error) {
+	scale := max(config.ExportScale, 1)
+	cellWidth, cellHeight := fontWidth*scale, fontHeight*scale
+	padding := max(config.ExportPadding, 0)
+	img := image.NewRGBA(image.Rect(0, 0, styledLinesWidth(lines)*cellWidth+2*padding, len(lines)*cellHeight+2*padding))
+	background := color.RGBA{R: palette.Background[0], G: palette.Background[1], B: palette.Background[2], A: 0xff}
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = background.R, background.G, background.B, background.A
+	}
+
+	for i, runs := range lines {
+		top := padding + i*cellHeight
+		for _, run := range runs {
+			foreground, runBackground, hasBackground := palette.RunColors(run.State)
+			left := padding + run.Column*cellWidth
+			if hasBackground {
+				for y := top; y < top+cellHeight; y++ {
+					for x := left; x < left+run.Width*cellWidth; x++ {
+						blendPixel(img, x, y, runBackground, 1)
+					}
+				}
+			}
+			if !run.State.Hidden {
+				text := run.Text
+				for x := left; text != ""; {
+					grapheme, width := NextGrapheme(text)
+					text = text[len(grapheme):]
+					if r := []rune(grapheme)[0]; r != ' ' && width > 0 {
+						_, fromFont := fontGlyphs[r]
+						mask := pngGlyphMask(r, width*cellWidth, cellHeight, scale)
+						for y, row := range mask {
+							// Slant italic text to the right from the bottom up
+							shift := 0
+							if run.State.Italic && fromFont {
+								shift = (cellHeight - 1 - y) / (5 * scale) * scale
+							}
+							for dx, coverage := range row {
+								blendPixel(img, x+dx+shift, top+y, foreground, coverage)
+								// Embolden glyphs of the font by drawing them twice
+								if run.State.Bold && fromFont {
+									blendPixel(img, x+dx+shift+scale, top+y, foreground, coverage)
+								}
+							}
+						}
+					}
+					x += width * cellWidth
+				}
+			}
+			if run.State.Underline {
+				for y := top + cellHeight - 2*scale; y < top+cellHeight-scale; y++ {
+					for x := left; x < left+run.Width*cellWidth; x++ {
+						blendPixel(img, x, y, foreground, 1)
+					}
+				}
+			}
+			if run.State.Strikethrough {
+				for y := top + cellHeight/2; y < top+cellHeight/2+scale; y++ {
+					for x := left; x < left+run.Width*cellWidth; x++ {
+						blendPixel(img, x, y, foreground, 1)
+					}
+				}
+			}
+		}
+	}
+
+	buffer := bytes.Buffer{}
+	if err := png.Encode(&buffer, img); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import "testing"
+
+// maskCoverage returns the amount of filled cells of the first row and column of a glyph mask
+func maskCoverage(mask [][]float64) (int, int) {
+	row, column := 0, 0
+	for _, value := range mask[0] {
+		if value > 0 {
+			row++
+		}
+	}
+	for _, line := range mask {
+		if line[0] > 0 {
+			column++
+		}
+	}
+	return row, column
+}
+
+func TestPNGGlyphMaskBlocks(t *testing.T) {
+	const width, height = 8, 16
+	tests := []struct {
+		r      rune
+		row    int
+		column int
+	}{
+		{'█', 8, 16},
+		{'▀', 8, 8},
+		{'▄', 0, 8},
+		{'▁', 0, 2},
+		{'▇', 0, 14},
+		{'▉', 7, 16},
+		{'▊', 6, 16},
+		{'▋', 5, 16},
+		{'▌', 4, 16},
+		{'▍', 3, 16},
+		{'▎', 2, 16},
+		{'▏', 1, 16},
+		{'▐', 4, 0},
+		{'▔', 8, 2},
+		{'▕', 1, 0},
+		{'▘', 4, 8},
+		{'▟', 4, 8},
+	}
+	for _, test := range tests {
+		row, column := maskCoverage(pngGlyphMask(test.r, width, height, 1))
+		if row != test.row || column != test.column {
+			t.Errorf("pngGlyphMask(%q) fills %d cells of the first row and %d of the first column, want %d and %d",
+				test.r, row, column, test.row, test.column)
+		}
+	}
+}
+
+func TestPNGGlyphMaskShades(t *testing.T) {
+	for i, r := range []rune("░▒▓") {
+		mask := pngGlyphMask(r, 8, 16, 1)
+		if want := float64(i+1) / 4; mask[8][4] != want {
+			t.Errorf("pngGlyphMask(%q) has a coverage of %v, want %v", r, mask[8][4], want)
+		}
+	}
+}
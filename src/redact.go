@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var RedactionRules = []string{
+	"hostname",
+	"local_ip",
+	"username",
+	"partition_devices",
+	"partition_labels",
+	"motherboard",
+	"home_mountpoints",
+}
+
+type Redactor struct {
+	Replacement string
+	values      []string
+	patterns    []*regexp.Regexp
+}
+
+func NewRedactor() (*Redactor, error) {
+	redactor := &Redactor{
+		Replacement: config.RedactReplacement,
+	}
+	for _, rule := range config.RedactRules {
+		if !slices.Contains(RedactionRules, rule) {
+			return nil, fmt.Errorf("unknown redaction rule '%s'", rule)
+		}
+	}
+	for _, pattern := range config.RedactPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern '%s': %s", pattern, err)
+		}
+		redactor.patterns = append(redactor.patterns, re)
+	}
+	if redactor.hasRule("hostname") {
+		if hostname, err := os.Hostname(); err == nil {
+			redactor.addValue(hostname)
+		}
+		if bytes, err := os.ReadFile("/etc/hostname"); err == nil {
+			redactor.addValue(strings.TrimSpace(string(bytes)))
+		}
+	}
+	if redactor.hasRule("username") {
+		if current, err := user.Current(); err == nil {
+			redactor.addValue(current.Username)
+		}
+	}
+	return redactor, nil
+}
+
+func (redactor *Redactor) hasRule(rule string) bool {
+	return slices.Contains(config.RedactRules, rule)
+}
+
+func (redactor *Redactor) addValue(value string) {
+	if strings.TrimSpace(value) == "" || slices.Contains(redactor.values, value) {
+		return
+	}
+	redactor.values = append(redactor.values, value)
+	// Replace longer values first so that values containing other values are fully masked
+	sort.SliceStable(redactor.values, func(i, j int) bool {
+		return len(redactor.values[i]) > len(redactor.values[j])
+	})
+}
+
+// RedactEnv masks sensitive fetch script variables and remembers their values so they can be masked in the output
+func (redactor *Redactor) RedactEnv(env map[string]string) {
+	redactVariable := func(key string) {
+		if value, ok := env[key]; ok && value != "" {
+			redactor.addValue(value)
+			env[key] = redactor.Replacement
+		}
+	}
+	if redactor.hasRule("local_ip") {
+		redactVariable("LOCAL_IPV4")
+	}
+	if redactor.hasRule("motherboard") {
+		redactVariable("MOTHERBOARD")
+	}
+	partitions, _ := strconv.Atoi(env["MOUNTED_PARTITIONS"])
+	for i := 1; i <= partitions; i++ {
+		prefix := "PARTITION" + strconv.Itoa(i)
+		if redactor.hasRule("partition_devices") {
+			redactVariable(prefix + "_DEVICE")
+		}
+		if redactor.hasRule("partition_labels") {
+			redactVariable(prefix + "_LABEL")
+		}
+		if mountpoint := env[prefix+"_MOUNTPOINT"]; redactor.hasRule("home_mountpoints") && strings.HasPrefix(mountpoint, "/home/") {
+			redactor.addValue(mountpoint)
+			env[prefix+"_MOUNTPOINT"] = "/home/" + redactor.Replacement
+		}
+	}
+}
+
+// RedactString masks every known sensitive value and every configured pattern in the given string
+func (redactor *Redactor) RedactString(str string) string {
+	for _, value := range redactor.values {
+		str = replaceWord(str, value, redactor.Replacement)
+	}
+	for _, pattern := range redactor.patterns {
+		str = pattern.ReplaceAllLiteralString(str, redactor.Replacement)
+	}
+	return str
+}
+
+// trailingSgrRegex matches an SGR sequence ending a string, which acts as a word boundary
+var trailingSgrRegex = regexp.MustCompile("\033\\[[0-9;]*m$")
+
+// replaceWord replaces whole word occurrences of old in str, treating escape sequences as word boundaries
+func replaceWord(str, old, new string) string {
+	builder := strings.Builder{}
+	for {
+		index := strings.Index(str, old)
+		if index == -1 {
+			builder.WriteString(str)
+			return builder.String()
+		}
+		end := index + len(old)
+		before, after := builder.String()+str[:index], str[end:]
+		boundaryBefore := !isWordChar(old[0]) || before == "" || !isWordChar(before[len(before)-1]) || trailingSgrRegex.MatchString(before)
+		boundaryAfter := !isWordChar(old[len(old)-1]) || after == "" || !isWordChar(after[0])
+		if boundaryBefore && boundaryAfter {
+			builder.WriteString(str[:index] + new)
+		} else {
+			builder.WriteString(str[:end])
+		}
+		str = after
+	}
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
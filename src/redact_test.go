@@ -0,0 +1,97 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestReplaceWord(t *testing.T) {
+	tests := []struct {
+		name string
+		str  string
+		old  string
+		want string
+	}{
+		{"whole words", "user@myhost: /home/user", "user", "***@myhost: /home/***"},
+		{"prefix of a word", "username user", "user", "username ***"},
+		{"suffix of a word", "superuser", "user", "superuser"},
+		{"underscore is a word character", "user_1 user", "user", "user_1 ***"},
+		{"repeated word", "useruser user", "user", "useruser ***"},
+		{"sgr before", "\033[1muser\033[0m", "user", "\033[1m***\033[0m"},
+		{"sgr before a longer word", "\033[1musername", "user", "\033[1musername"},
+		{"sgr with parameters", "\033[38;5;208muser", "user", "\033[38;5;208m***"},
+		{"longer address", "192.168.1.10 and 192.168.1.100", "192.168.1.10", "*** and 192.168.1.100"},
+		{"value starting with a separator", "a.local", ".local", "a***"},
+		{"not found", "nothing here", "user", "nothing here"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := replaceWord(test.str, test.old, "***"); got != test.want {
+				t.Errorf("replaceWord(%q, %q) = %q, want %q", test.str, test.old, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRedactString(t *testing.T) {
+	redactor := &Redactor{Replacement: "***"}
+	redactor.addValue("host")
+	redactor.addValue("host.lan")
+	redactor.addValue("host")
+	redactor.addValue("  ")
+	redactor.patterns = []*regexp.Regexp{regexp.MustCompile("[0-9a-f]{2}(:[0-9a-f]{2}){5}")}
+
+	tests := []struct {
+		str  string
+		want string
+	}{
+		{"host.lan host hostname", "*** *** hostname"},
+		{"MAC: 00:1a:2b:3c:4d:5e", "MAC: ***"},
+		{"   ", "   "},
+	}
+	for _, test := range tests {
+		if got := redactor.RedactString(test.str); got != test.want {
+			t.Errorf("RedactString(%q) = %q, want %q", test.str, got, test.want)
+		}
+	}
+}
+
+func TestRedactEnv(t *testing.T) {
+	savedRules := config.RedactRules
+	config.RedactRules = []string{"local_ip", "partition_labels", "home_mountpoints"}
+	t.Cleanup(func() {
+		config.RedactRules = savedRules
+	})
+
+	redactor := &Redactor{Replacement: "***"}
+	env := map[string]string{
+		"LOCAL_IPV4":            "192.168.1.10",
+		"MOTHERBOARD":           "ACME X100",
+		"MOUNTED_PARTITIONS":    "2",
+		"PARTITION1_DEVICE":     "/dev/sda1",
+		"PARTITION1_LABEL":      "Backups",
+		"PARTITION1_MOUNTPOINT": "/home/alice",
+		"PARTITION2_LABEL":      "",
+		"PARTITION2_MOUNTPOINT": "/",
+	}
+	redactor.RedactEnv(env)
+
+	want := map[string]string{
+		"LOCAL_IPV4":            "***",
+		"MOTHERBOARD":           "ACME X100",
+		"PARTITION1_DEVICE":     "/dev/sda1",
+		"PARTITION1_LABEL":      "***",
+		"PARTITION1_MOUNTPOINT": "/home/***",
+		"PARTITION2_LABEL":      "",
+		"PARTITION2_MOUNTPOINT": "/",
+	}
+	for key, value := range want {
+		if env[key] != value {
+			t.Errorf("%s = %q, want %q", key, env[key], value)
+		}
+	}
+	str := "Backups on /home/alice at 192.168.1.10"
+	if got, want := redactor.RedactString(str), "*** on *** at ***"; got != want {
+		t.Errorf("RedactString(%q) = %q, want %q", str, got, want)
+	}
+}
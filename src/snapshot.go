@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+)
+
+var snapshotOutput = ""
+var snapshotNoRedact = false
+
+func setupSnapshotFlags(flags *flag.FlagSet) {
+	flags.StringVar(&snapshotOutput, "o", "", "Write the snapshot to the given file instead of stdout")
+	flags.BoolVar(&snapshotNoRedact, "no-redact", false, "Do not mask sensitive information")
+}
+
+func runSnapshot(args []string) int {
+	// Snapshots are meant to be shared so they are redacted unless explicitly requested otherwise
+	config.Redact = !snapshotNoRedact
+	config.EnableHistory = false
+	config.LogoImage = ""
+	var redactor *Redactor
+	if config.Redact {
+		var err error
+		redactor, err = NewRedactor()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not setup redaction: %s\n", err)
+			return 1
+		}
+	}
+	redact := func(str string) string {
+		if redactor == nil {
+			return str
+		}
+		return redactor.RedactString(str)
+	}
+
+	builder := strings.Builder{}
+	section := func(title, content string) {
+		builder.WriteString(fmt.Sprintf("## %s\n%s\n\n", title, strings.TrimRight(content, "\n")))
+	}
+
+	builder.WriteString("# Stormfetch snapshot\n\n")
+	section("Version", fmt.Sprintf("Version: %s\nSystem config directory: %s\nGenerated: %s",
+		version, systemConfigDir, time.Now().Format(time.RFC3339)))
+	section("Files", redact(fmt.Sprintf("Config file: %s\nFetch script: %s\nAscii art: %s",
+		configPath, fetchScriptPath, GetAsciiArtPath(defaultAsciiID()))))
+	if bytes, err := os.ReadFile("/etc/os-release"); err == nil {
+		section("os-release", redact(string(bytes)))
+	}
+	if out, err := exec.Command("uname", "-srm").Output(); err == nil {
+		section("Kernel", string(out))
+	}
+	if bytes, err := yaml.Marshal(config); err == nil {
+		section("Configuration", redact(string(bytes)))
+	}
+
+	env := SetupFetchEnv(nil, redactor)
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	variables := ""
+	for _, key := range keys {
+		variables += fmt.Sprintf("%s=%s\n", key, env[key])
+	}
+	section("Variables", redact(variables))
+
+	final, err := RenderStormfetch()
+	if err != nil {
+		section("Output", "Error: "+err.Error())
+	} else {
+		section("Output", StripAnsii(final))
+	}
+
+	if snapshotOutput == "" {
+		fmt.Print(builder.String())
+		return 0
+	}
+	if err := os.WriteFile(snapshotOutput, []byte(builder.String()), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not write snapshot: %s\n", err)
+		return 1
+	}
+	return 0
+}
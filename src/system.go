@@ -1,15 +1,19 @@
 package main
 
 import (
+	"fmt"
 	"github.com/mitchellh/go-ps"
 	"os"
 	"os/exec"
 	"path"
+	"slices"
 	"strings"
 )
 
 type DistroInfo struct {
 	ID        string
+	VariantID string
+	IDLike    []string
 	LongName  string
 	ShortName string
 }
@@ -34,6 +38,12 @@ func GetDistroInfo() DistroInfo {
 	if id, ok := releaseMap["ID"]; ok {
 		info.ID = id
 	}
+	if variantID, ok := releaseMap["VARIANT_ID"]; ok {
+		info.VariantID = variantID
+	}
+	if idLike, ok := releaseMap["ID_LIKE"]; ok {
+		info.IDLike = strings.Fields(idLike)
+	}
 	if longName, ok := releaseMap["PRETTY_NAME"]; ok && info.LongName == "Unknown" {
 		info.LongName = longName
 	}
@@ -43,48 +53,215 @@ func GetDistroInfo() DistroInfo {
 	return info
 }
 
-func GetDistroAsciiArt() string {
-	defaultAscii :=
-		`    .--.
+// EmbeddedAsciiArts holds the arts built into stormfetch, used when no art file is found
+var EmbeddedAsciiArts = map[string]string{
+	"tux": `    .--.
    |o_o |
    |:_/ |
   //   \ \
  (|     | )
 /'\_   _/'\
-\___)=(___/ `
-	var id string
-	if config.Ascii == "auto" {
-		id = GetDistroInfo().ID
-	} else {
-		id = config.Ascii
+\___)=(___/ `,
+}
+
+// AsciiArtDir is a directory ascii art files are looked up in, either from the user or the system config
+type AsciiArtDir struct {
+	Source string
+	Path   string
+}
+
+// AsciiArtSource describes where the ascii art with a given ID is read from
+type AsciiArtSource struct {
+	ID     string
+	Source string
+	Path   string
+}
+
+// GetAsciiArtDirs returns the directories ascii art files are looked up in, by order of priority
+func GetAsciiArtDirs() []AsciiArtDir {
+	var dirs []AsciiArtDir
+	if userConfDir, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, AsciiArtDir{Source: "user", Path: path.Join(userConfDir, "stormfetch/ascii/")})
 	}
-	userConfDir, err := os.UserConfigDir()
-	if err != nil {
-		if _, err := os.Stat(path.Join(systemConfigDir, "stormfetch/ascii/", id)); err == nil {
-			bytes, err := os.ReadFile(path.Join(systemConfigDir, "stormfetch/ascii/", id))
-			if err != nil {
-				return defaultAscii
-			}
-			return string(bytes)
-		} else {
-			return defaultAscii
+	return append(dirs, AsciiArtDir{Source: "system", Path: path.Join(systemConfigDir, "stormfetch/ascii/")})
+}
+
+// GetAsciiArtPath returns the path of the ascii art file with the given ID or an empty string if it does not exist
+func GetAsciiArtPath(id string) string {
+	for _, dir := range GetAsciiArtDirs() {
+		if _, err := os.Stat(path.Join(dir.Path, id)); err == nil {
+			return path.Join(dir.Path, id)
 		}
 	}
-	if _, err := os.Stat(path.Join(userConfDir, "stormfetch/ascii/", id)); err == nil {
-		bytes, err := os.ReadFile(path.Join(userConfDir, "stormfetch/ascii/", id))
+	return ""
+}
+
+// ListAsciiArtSources returns every available ascii art along with where it is read from, arts of the user config taking precedence
+func ListAsciiArtSources() []AsciiArtSource {
+	var sources []AsciiArtSource
+	found := func(id string) bool {
+		return slices.ContainsFunc(sources, func(source AsciiArtSource) bool {
+			return source.ID == id
+		})
+	}
+	for _, dir := range GetAsciiArtDirs() {
+		entries, err := os.ReadDir(dir.Path)
 		if err != nil {
-			return defaultAscii
+			continue
 		}
-		return string(bytes)
-	} else if _, err := os.Stat(path.Join(systemConfigDir, "stormfetch/ascii/", id)); err == nil {
-		bytes, err := os.ReadFile(path.Join(systemConfigDir, "stormfetch/ascii/", id))
+		for _, entry := range entries {
+			if !entry.IsDir() && !found(entry.Name()) {
+				sources = append(sources, AsciiArtSource{ID: entry.Name(), Source: dir.Source, Path: path.Join(dir.Path, entry.Name())})
+			}
+		}
+	}
+	for id := range EmbeddedAsciiArts {
+		if !found(id) {
+			sources = append(sources, AsciiArtSource{ID: id, Source: "embedded"})
+		}
+	}
+	slices.SortFunc(sources, func(a, b AsciiArtSource) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return sources
+}
+
+// ListAsciiArts returns the IDs of all ascii art files found in the user and system config directories along with the embedded ones
+func ListAsciiArts() []string {
+	var ids []string
+	for _, source := range ListAsciiArtSources() {
+		ids = append(ids, source.ID)
+	}
+	return ids
+}
+
+// ReadAsciiArt returns the content of the ascii art with the given ID, looking up art files before the embedded arts
+func ReadAsciiArt(id string) (string, error) {
+	if asciiPath := GetAsciiArtPath(id); asciiPath != "" {
+		bytes, err := os.ReadFile(asciiPath)
 		if err != nil {
-			return defaultAscii
+			return "", err
 		}
-		return strings.TrimRight(string(bytes), "\n\t ")
+		return string(bytes), nil
+	}
+	if ascii, ok := EmbeddedAsciiArts[id]; ok {
+		return ascii, nil
+	}
+	return "", fmt.Errorf("ascii art '%s' not found", id)
+}
+
+// ParseAsciiHeader splits the '#/' color header from an ascii art and returns its colors along with the remaining art.
+// The colors may be followed by options after a '|', such as the delay between the frames of animated arts
+func ParseAsciiHeader(ascii string) ([]string, string, error) {
+	if !strings.HasPrefix(ascii, "#/") {
+		return nil, ascii, nil
+	}
+	firstLine := strings.Split(ascii, "\n")[0]
+	header, options, _ := strings.Cut(strings.TrimPrefix(firstLine, "#/"), "|")
+	if _, err := parseAsciiHeaderOptions(options); err != nil {
+		return nil, ascii, err
+	}
+	if strings.TrimSpace(header) == "" {
+		return nil, strings.TrimPrefix(ascii, firstLine+"\n"), nil
+	}
+	var colors []string
+	for i, color := range strings.Split(header, ";") {
+		if _, err := ParseColorSpec(color); err != nil {
+			return nil, ascii, fmt.Errorf("invalid color C%d '%s': %s", i+1, color, err)
+		}
+		colors = append(colors, strings.TrimSpace(color))
+	}
+	return colors, strings.TrimPrefix(ascii, firstLine+"\n"), nil
+}
+
+var AsciiSizes = []string{"auto", "large", "small", "tiny"}
+
+// DefaultAsciiAliases maps the IDs of distributions without an art of their own, nor a fitting ID_LIKE, to the art they use
+var DefaultAsciiAliases = map[string]string{
+	"rhel":          "fedora",
+	"centos":        "fedora",
+	"suse":          "opensuse",
+	"sles":          "opensuse",
+	"opensuse-leap": "opensuse",
+}
+
+// GetAsciiArtCandidates returns the IDs of the arts that may be used for a distribution, from the most to the least specific:
+// the ID qualified with the variant (e.g. fedora-silverblue), the ID itself and then every ID it is like.
+// IDs found in the ascii_aliases map of the config are followed by the art they point to, used when they have no art of their own
+func GetAsciiArtCandidates(info DistroInfo) []string {
+	var candidates []string
+	add := func(id string) {
+		for _, candidate := range []string{id, config.AsciiAliases[id]} {
+			if candidate != "" && !slices.Contains(candidates, candidate) {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	if info.VariantID != "" {
+		add(info.ID + "-" + info.VariantID)
+	}
+	add(info.ID)
+	for _, id := range info.IDLike {
+		add(id)
+	}
+	return candidates
+}
+
+// ResolveAsciiArtID returns the first art among the candidates of a distribution that exists, or false if none of them does
+func ResolveAsciiArtID(info DistroInfo) (string, bool) {
+	for _, id := range GetAsciiArtCandidates(info) {
+		if _, ok := EmbeddedAsciiArts[id]; ok || GetAsciiArtPath(id) != "" {
+			return id, true
+		}
+	}
+	return info.ID, false
+}
+
+// AsciiArtVariant is the art of a distribution in one of the sizes of AsciiSizes
+type AsciiArtVariant struct {
+	Size  string
+	Ascii string
+}
+
+// asciiSizeSuffixes holds the suffix appended to the ID of an art for each size, from the largest to the smallest
+var asciiSizeSuffixes = map[string]string{"large": "", "small": "_small", "tiny": "_tiny"}
+
+// GetDistroAsciiArts returns every size variant of the distribution art found, from the largest to the smallest
+func GetDistroAsciiArts() []AsciiArtVariant {
+	var id string
+	if config.Ascii == "auto" {
+		id, _ = ResolveAsciiArtID(GetDistroInfo())
 	} else {
-		return defaultAscii
+		id = config.Ascii
+	}
+	var variants []AsciiArtVariant
+	for _, size := range AsciiSizes[1:] {
+		if ascii, err := ReadAsciiArt(id + asciiSizeSuffixes[size]); err == nil {
+			variants = append(variants, AsciiArtVariant{Size: size, Ascii: strings.TrimRight(ascii, "\n\t ")})
+		}
 	}
+	if len(variants) == 0 {
+		variants = append(variants, AsciiArtVariant{Size: "large", Ascii: strings.TrimRight(EmbeddedAsciiArts["tux"], "\n\t ")})
+	}
+	return variants
+}
+
+// SelectAsciiArt returns the variant of the given size, or the closest smaller one if it does not exist.
+// The largest variant is returned for the auto size
+func SelectAsciiArt(variants []AsciiArtVariant, size string) string {
+	wanted := max(slices.Index(AsciiSizes, size), 1)
+	selected := variants[0]
+	for _, variant := range variants {
+		if slices.Index(AsciiSizes, variant.Size) > wanted {
+			break
+		}
+		selected = variant
+	}
+	return selected.Ascii
+}
+
+func GetDistroAsciiArt() string {
+	return SelectAsciiArt(GetDistroAsciiArts(), config.AsciiSize)
 }
 
 func GetInitSystem() string {
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"maps"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+// setupAsciiArtDirs points the user and system config directories to temporary directories holding the given arts
+func setupAsciiArtDirs(t *testing.T, userArts, systemArts []string) {
+	userDir, systemDir := t.TempDir(), t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", userDir)
+	savedSystemConfigDir := systemConfigDir
+	systemConfigDir = systemDir
+	t.Cleanup(func() {
+		systemConfigDir = savedSystemConfigDir
+	})
+	for dir, ids := range map[string][]string{userDir: userArts, systemDir: systemArts} {
+		if err := os.MkdirAll(path.Join(dir, "stormfetch/ascii"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range ids {
+			if err := os.WriteFile(path.Join(dir, "stormfetch/ascii", id), []byte("#/1\n"+id+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestGetAsciiArtCandidates(t *testing.T) {
+	tests := []struct {
+		name string
+		info DistroInfo
+		want []string
+	}{
+		{"id only", DistroInfo{ID: "arch"}, []string{"arch"}},
+		{"variant first", DistroInfo{ID: "fedora", VariantID: "silverblue"}, []string{"fedora-silverblue", "fedora"}},
+		{"id like", DistroInfo{ID: "endeavouros", IDLike: []string{"arch"}}, []string{"endeavouros", "arch"}},
+		{"alias follows the id", DistroInfo{ID: "rhel"}, []string{"rhel", "fedora"}},
+		{"aliases of id like", DistroInfo{ID: "rocky", IDLike: []string{"rhel", "centos", "fedora"}}, []string{"rocky", "rhel", "fedora", "centos"}},
+		{"duplicates are dropped", DistroInfo{ID: "sles", IDLike: []string{"suse", "opensuse"}}, []string{"sles", "opensuse", "suse"}},
+		{"empty", DistroInfo{}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := GetAsciiArtCandidates(test.info); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("GetAsciiArtCandidates(%+v) = %q, want %q", test.info, got, test.want)
+			}
+		})
+	}
+}
+
+func TestResolveAsciiArtID(t *testing.T) {
+	savedAliases := config.AsciiAliases
+	config.AsciiAliases = maps.Clone(DefaultAsciiAliases)
+	config.AsciiAliases["endeavouros"] = "arch"
+	t.Cleanup(func() {
+		config.AsciiAliases = savedAliases
+	})
+
+	tests := []struct {
+		name       string
+		userArts   []string
+		systemArts []string
+		info       DistroInfo
+		want       string
+		found      bool
+	}{
+		{"own art is used over the alias", []string{"rhel"}, []string{"fedora"}, DistroInfo{ID: "rhel"}, "rhel", true},
+		{"own system art is used over the alias", nil, []string{"centos", "fedora"}, DistroInfo{ID: "centos"}, "centos", true},
+		{"alias without an own art", nil, []string{"fedora"}, DistroInfo{ID: "rhel"}, "fedora", true},
+		{"configured alias", nil, []string{"arch"}, DistroInfo{ID: "endeavouros"}, "arch", true},
+		{"variant", []string{"fedora-silverblue"}, []string{"fedora"}, DistroInfo{ID: "fedora", VariantID: "silverblue"}, "fedora-silverblue", true},
+		{"id like", nil, []string{"ubuntu"}, DistroInfo{ID: "pop", IDLike: []string{"ubuntu", "debian"}}, "ubuntu", true},
+		{"embedded art", nil, nil, DistroInfo{ID: "unknown", IDLike: []string{"tux"}}, "tux", true},
+		{"not found", nil, nil, DistroInfo{ID: "unknown"}, "unknown", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupAsciiArtDirs(t, test.userArts, test.systemArts)
+			id, found := ResolveAsciiArtID(test.info)
+			if id != test.want || found != test.found {
+				t.Errorf("ResolveAsciiArtID(%+v) = %q, %t, want %q, %t", test.info, id, found, test.want, test.found)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+	"unsafe"
+)
+
+type TerminalSize struct {
+	Columns     int
+	Rows        int
+	PixelWidth  int
+	PixelHeight int
+}
+
+// GetTerminalSize returns the size of the terminal stdout is connected to, falling back to the COLUMNS and LINES variables
+func GetTerminalSize() (TerminalSize, bool) {
+	winsize := struct {
+		Row, Col, Xpixel, Ypixel uint16
+	}{}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, os.Stdout.Fd(), syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&winsize)))
+	if errno == 0 && winsize.Col != 0 {
+		return TerminalSize{
+			Columns:     int(winsize.Col),
+			Rows:        int(winsize.Row),
+			PixelWidth:  int(winsize.Xpixel),
+			PixelHeight: int(winsize.Ypixel),
+		}, true
+	}
+	columns, _ := strconv.Atoi(os.Getenv("COLUMNS"))
+	rows, _ := strconv.Atoi(os.Getenv("LINES"))
+	if columns <= 0 {
+		return TerminalSize{}, false
+	}
+	return TerminalSize{Columns: columns, Rows: rows}, true
+}
+
+// IsTerminal reports whether a file is connected to a terminal
+func IsTerminal(file *os.File) bool {
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&termios)))
+	return errno == 0
+}
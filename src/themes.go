@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+)
+
+// Theme holds the C1-C6 colors along with the colors of the label, value and separator roles used by the fetch script.
+// The background, foreground and padding replace the export_* config keys of the files written by --output
+type Theme struct {
+	Colors     []string `yaml:"colors"`
+	Label      string   `yaml:"label"`
+	Value      string   `yaml:"value"`
+	Separator  string   `yaml:"separator"`
+	Background string   `yaml:"background"`
+	Foreground string   `yaml:"foreground"`
+	Padding    *int     `yaml:"padding"`
+}
+
+// defaultRoles maps the roles to color slots when no theme is set
+var defaultRoles = map[string]string{
+	"C_LABEL":     "C3",
+	"C_VALUE":     "C4",
+	"C_SEPARATOR": "C3",
+}
+
+// GetThemePath returns the path of a theme file in the user or system config directory
+func GetThemePath(name string) string {
+	return FindConfigFile("themes", name+".yaml")
+}
+
+// ListThemes returns the names of all themes found in the user and system config directories
+func ListThemes() []string {
+	return ListConfigFiles("themes", ".yaml")
+}
+
+// GetTheme reads the theme set in the config, returning nil if none is set
+func GetTheme() (*Theme, error) {
+	if config.Theme == "" || config.Theme == "none" {
+		return nil, nil
+	}
+	themePath := GetThemePath(config.Theme)
+	if themePath == "" {
+		return nil, fmt.Errorf("theme '%s' not found", config.Theme)
+	}
+	bytes, err := os.ReadFile(themePath)
+	if err != nil {
+		return nil, err
+	}
+	theme := &Theme{}
+	if err := yaml.Unmarshal(bytes, theme); err != nil {
+		return nil, fmt.Errorf("could not parse theme %s: %s", themePath, err)
+	}
+	if len(theme.Colors) > 6 {
+		return nil, fmt.Errorf("theme %s defines %d colors, expected at most 6", themePath, len(theme.Colors))
+	}
+	return theme, nil
+}
+
+// Roles returns the color spec of each role, falling back to the default slot of a role the theme leaves empty
+func (theme *Theme) Roles() map[string]string {
+	roles := map[string]string{
+		"C_LABEL":     theme.Label,
+		"C_VALUE":     theme.Value,
+		"C_SEPARATOR": theme.Separator,
+	}
+	for role, spec := range roles {
+		if spec == "" {
+			roles[role] = defaultRoles[role]
+		}
+	}
+	return roles
+}
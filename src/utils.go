@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -27,10 +29,13 @@ func FormatBytes(bytes uint64) string {
 	return fmt.Sprintf("%.1fYiB", bf)
 }
 
+const ansiPattern = "[\u001B\u009B][[\\]()#;?]*(?:(?:(?:[a-zA-Z\\d]*(?:;[a-zA-Z\\d]*)*)?\u0007)|(?:(?:\\d{1,4}(?:;\\d{0,4})*)?[\\dA-PRZcf-ntqry=><~]))"
+
+var ansiRegex = regexp.MustCompile(ansiPattern)
+var leadingAnsiRegex = regexp.MustCompile("^(?:" + ansiPattern + ")")
+
 func StripAnsii(str string) string {
-	const ansi = "[\u001B\u009B][[\\]()#;?]*(?:(?:(?:[a-zA-Z\\d]*(?:;[a-zA-Z\\d]*)*)?\u0007)|(?:(?:\\d{1,4}(?:;\\d{0,4})*)?[\\dA-PRZcf-ntqry=><~]))"
-	var re = regexp.MustCompile(ansi)
-	return re.ReplaceAllString(str, "")
+	return ansiRegex.ReplaceAllString(str, "")
 }
 
 func ReadKeyValueFile(filepath string) (map[string]string, error) {
@@ -56,3 +61,40 @@ func ReadKeyValueFile(filepath string) (map[string]string, error) {
 	}
 	return ret, nil
 }
+
+// FindConfigFile returns the path of a file of a stormfetch config subdirectory, preferring the user config directory over the system one
+func FindConfigFile(dir, file string) string {
+	if userConfDir, err := os.UserConfigDir(); err == nil {
+		if _, err := os.Stat(path.Join(userConfDir, "stormfetch", dir, file)); err == nil {
+			return path.Join(userConfDir, "stormfetch", dir, file)
+		}
+	}
+	if _, err := os.Stat(path.Join(systemConfigDir, "stormfetch", dir, file)); err == nil {
+		return path.Join(systemConfigDir, "stormfetch", dir, file)
+	}
+	return ""
+}
+
+// ListConfigFiles returns the names without extension of the files of a stormfetch config subdirectory in the user and system config directories
+func ListConfigFiles(dir, extension string) []string {
+	var dirs []string
+	if userConfDir, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, path.Join(userConfDir, "stormfetch", dir))
+	}
+	dirs = append(dirs, path.Join(systemConfigDir, "stormfetch", dir))
+	var names []string
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutSuffix(entry.Name(), extension)
+			if !entry.IsDir() && ok && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
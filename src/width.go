@@ -0,0 +1,142 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRanges holds the East Asian Wide and Fullwidth ranges along with emoji presented as two cells wide by default
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC}, {0x23F0, 0x23F0},
+	{0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267F, 0x267F},
+	{0x2693, 0x2693}, {0x26A1, 0x26A1}, {0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5},
+	{0x26CE, 0x26CE}, {0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B}, {0x2728, 0x2728},
+	{0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797},
+	{0x27B0, 0x27B0}, {0x27BF, 0x27BF}, {0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55},
+	{0x2E80, 0x303E}, {0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19}, {0xFE30, 0xFE6F},
+	{0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4}, {0x17000, 0x18AFF}, {0x1B000, 0x1B2FF},
+	{0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF}, {0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F202},
+	{0x1F210, 0x1F23B}, {0x1F240, 0x1F248}, {0x1F250, 0x1F251}, {0x1F260, 0x1F265}, {0x1F300, 0x1F320},
+	{0x1F32D, 0x1F335}, {0x1F337, 0x1F37C}, {0x1F37E, 0x1F393}, {0x1F3A0, 0x1F3CA}, {0x1F3CF, 0x1F3D3},
+	{0x1F3E0, 0x1F3F0}, {0x1F3F4, 0x1F3F4}, {0x1F3F8, 0x1F43E}, {0x1F440, 0x1F440}, {0x1F442, 0x1F4FC},
+	{0x1F4FF, 0x1F53D}, {0x1F54B, 0x1F54E}, {0x1F550, 0x1F567}, {0x1F57A, 0x1F57A}, {0x1F595, 0x1F596},
+	{0x1F5A4, 0x1F5A4}, {0x1F5FB, 0x1F64F}, {0x1F680, 0x1F6C5}, {0x1F6CC, 0x1F6CC}, {0x1F6D0, 0x1F6D2},
+	{0x1F6D5, 0x1F6D7}, {0x1F6DC, 0x1F6DF}, {0x1F6EB, 0x1F6EC}, {0x1F6F4, 0x1F6FC}, {0x1F7E0, 0x1F7EB},
+	{0x1F7F0, 0x1F7F0}, {0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945}, {0x1F947, 0x1F9FF}, {0x1FA70, 0x1FAFF},
+	{0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+func inRanges(r rune, ranges [][2]rune) bool {
+	i := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i][1] >= r
+	})
+	return i < len(ranges) && ranges[i][0] <= r
+}
+
+// RuneWidth returns the amount of terminal cells taken by a single rune
+func RuneWidth(r rune) int {
+	switch {
+	case r == 0 || r < 0x20 || (r >= 0x7F && r < 0xA0):
+		return 0
+	case r < 0x300:
+		return 1
+	case r == 0x200B || (r >= 0x1160 && r <= 0x11FF):
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case inRanges(r, wideRanges):
+		return 2
+	}
+	return 1
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func isEmojiModifier(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+// NextGrapheme returns the first grapheme cluster of a string along with the amount of terminal cells it takes
+func NextGrapheme(str string) (string, int) {
+	if str == "" {
+		return "", 0
+	}
+	first, size := utf8.DecodeRuneInString(str)
+	width := RuneWidth(first)
+	end := size
+	joined := false
+	for end < len(str) {
+		r, size := utf8.DecodeRuneInString(str[end:])
+		switch {
+		case joined:
+			// The rune following a zero width joiner is part of the cluster
+			joined = false
+		case r == 0x200D:
+			joined = true
+		case r == 0xFE0F:
+			// Emoji presentation selector
+			width = 2
+		case r == 0xFE0E:
+			// Text presentation selector
+			width = 1
+		case isEmojiModifier(r) && width == 2:
+		case isRegionalIndicator(first) && isRegionalIndicator(r) && end == utf8.RuneLen(first):
+			// Flags are made of two regional indicators
+			width = 2
+		case RuneWidth(r) == 0 && !unicode.IsControl(r):
+		default:
+			return str[:end], width
+		}
+		end += size
+	}
+	return str[:end], width
+}
+
+// StringWidth returns the amount of terminal cells taken by a string without escape sequences
+func StringWidth(str string) int {
+	width := 0
+	for str != "" {
+		grapheme, w := NextGrapheme(str)
+		width += w
+		str = str[len(grapheme):]
+	}
+	return width
+}
+
+// DisplayWidth returns the amount of terminal cells taken by a string, ignoring its escape sequences
+func DisplayWidth(str string) int {
+	return StringWidth(StripAnsii(str))
+}
+
+// TruncateString shortens a string to the given amount of terminal cells, ending it with an ellipsis and keeping its escape sequences intact
+func TruncateString(str string, width int) string {
+	if DisplayWidth(str) <= width {
+		return str
+	}
+	if width <= 0 {
+		return ""
+	}
+	builder := strings.Builder{}
+	current := 0
+	for str != "" {
+		if escape := leadingAnsiRegex.FindString(str); escape != "" {
+			builder.WriteString(escape)
+			str = str[len(escape):]
+			continue
+		}
+		grapheme, w := NextGrapheme(str)
+		if current+w > width-1 {
+			break
+		}
+		builder.WriteString(grapheme)
+		current += w
+		str = str[len(grapheme):]
+	}
+	return builder.String() + "…\033[0m"
+}
@@ -0,0 +1,89 @@
+package main
+
+import "testing"
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		str  string
+		want int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"\033[1;31mred\033[0m", 3},
+		{"\033[38;2;1;2;3mrgb\033[0m text", 8},
+		{"日本語", 6},
+		{"ａｂ", 4},
+		{"e\u0301", 1},
+		{"\u200b", 0},
+		{"\t", 0},
+		{"\u2764\ufe0f", 2},
+		{"\u263a\ufe0e", 1},
+		{"👍🏽", 2},
+		{"👩\u200d💻", 2},
+		{"🇫🇷", 2},
+		{"🇫🇷🇩🇪", 4},
+		{"한국어", 6},
+		{"▁▂▃█", 4},
+		{"⠿⠇", 2},
+	}
+	for _, test := range tests {
+		if got := DisplayWidth(test.str); got != test.want {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", test.str, got, test.want)
+		}
+	}
+}
+
+func TestNextGrapheme(t *testing.T) {
+	tests := []struct {
+		str      string
+		grapheme string
+		width    int
+	}{
+		{"", "", 0},
+		{"ab", "a", 1},
+		{"e\u0301x", "e\u0301", 1},
+		{"日x", "日", 2},
+		{"👩\u200d💻x", "👩\u200d💻", 2},
+		{"🇫🇷🇩🇪", "🇫🇷", 2},
+		{"👍🏽!", "👍🏽", 2},
+		{"\tx", "\t", 0},
+	}
+	for _, test := range tests {
+		grapheme, width := NextGrapheme(test.str)
+		if grapheme != test.grapheme || width != test.width {
+			t.Errorf("NextGrapheme(%q) = %q, %d, want %q, %d", test.str, grapheme, width, test.grapheme, test.width)
+		}
+	}
+}
+
+func TestTruncateString(t *testing.T) {
+	tests := []struct {
+		str   string
+		width int
+		want  string
+	}{
+		{"hello", 5, "hello"},
+		{"hello", 10, "hello"},
+		{"hello", 4, "hel…\033[0m"},
+		{"hello", 1, "…\033[0m"},
+		{"hello", 0, ""},
+		{"hello", -1, ""},
+		{"\033[31mhello\033[0m", 5, "\033[31mhello\033[0m"},
+		{"\033[31mhello\033[0m", 3, "\033[31mhe…\033[0m"},
+		// Escape sequences before the cut are kept so the state of the rest of the line is right
+		{"a\033[1mbcdef", 3, "a\033[1mb…\033[0m"},
+		// Wide characters are not split in half
+		{"日本語", 4, "日…\033[0m"},
+		{"日本語", 5, "日本…\033[0m"},
+		{"e\u0301e\u0301e\u0301", 2, "e\u0301…\033[0m"},
+	}
+	for _, test := range tests {
+		got := TruncateString(test.str, test.width)
+		if got != test.want {
+			t.Errorf("TruncateString(%q, %d) = %q, want %q", test.str, test.width, got, test.want)
+		}
+		if test.width > 0 && DisplayWidth(got) > test.width {
+			t.Errorf("TruncateString(%q, %d) is %d cells wide", test.str, test.width, DisplayWidth(got))
+		}
+	}
+}